@@ -0,0 +1,102 @@
+package arrays
+
+import (
+	"context"
+	"time"
+)
+
+type batchConfig struct {
+	clock Clock
+}
+
+// BatchOption configures ChanBatch.
+type BatchOption func(*batchConfig)
+
+// WithBatchClock sets the clock used for latency timers, SystemClock by default.
+func WithBatchClock(clock Clock) BatchOption {
+	return func(c *batchConfig) {
+		c.clock = clock
+	}
+}
+
+// ChanBatch groups values received from the input channel into slices of up to size elements.
+// A batch is emitted when it reaches size elements or maxLatency has passed since its first element
+// was received, whichever happens first. If maxLatency is not positive, only size is taken into account.
+// The remaining values are flushed when the input channel is closed.
+// The returned channel is closed when the input channel is closed or the context is done,
+// in the latter case the pending batch is dropped.
+func ChanBatch[I any](ctx context.Context, in <-chan I, size int, maxLatency time.Duration, opts ...BatchOption) <-chan []I {
+	c := batchConfig{clock: SystemClock}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	if size < 1 {
+		size = 1
+	}
+
+	out := make(chan []I)
+
+	go func() {
+		defer close(out)
+
+		var (
+			batch   []I
+			timer   Timer
+			timeout <-chan time.Time
+		)
+
+		flush := func() bool {
+			if timer != nil {
+				timer.Stop()
+				timer, timeout = nil, nil
+			}
+
+			if len(batch) == 0 {
+				return true
+			}
+
+			r := batch
+			batch = nil
+
+			return send(ctx, out, r)
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timeout:
+				timer, timeout = nil, nil
+
+				if !flush() {
+					return
+				}
+			case v, ok := <-in:
+				if !ok {
+					flush()
+
+					return
+				}
+
+				if batch == nil {
+					batch = make([]I, 0, size)
+
+					if maxLatency > 0 {
+						timer = c.clock.NewTimer(maxLatency)
+						timeout = timer.C()
+					}
+				}
+
+				batch = append(batch, v)
+
+				if len(batch) >= size && !flush() {
+					return
+				}
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,98 @@
+package arrays_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+func testReceive[I any](t *testing.T, c <-chan I) (I, bool) {
+	t.Helper()
+
+	select {
+	case v, ok := <-c:
+		return v, ok
+	case <-time.After(5 * time.Second):
+		t.Fatal("nothing received")
+	}
+
+	return *new(I), false
+}
+
+func TestChanBatchSize(t *testing.T) {
+	t.Parallel()
+
+	got := testCollect(t, arrays.ChanBatch(context.Background(), testSource(1, 2, 3, 4, 5), 2, 0))
+
+	want := [][]int{{1, 2}, {3, 4}, {5}}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	for i := range want {
+		if len(got[i]) != len(want[i]) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+
+		for j, v := range want[i] {
+			if got[i][j] != v {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		}
+	}
+}
+
+func TestChanBatchLatency(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock()
+	in := make(chan int)
+	out := arrays.ChanBatch(context.Background(), in, 10, time.Second, arrays.WithBatchClock(clock))
+
+	in <- 1
+	clock.WaitTimer(t)
+	in <- 2
+
+	clock.Advance(999 * time.Millisecond)
+
+	select {
+	case b := <-out:
+		t.Fatalf("got batch %v before latency passed", b)
+	default:
+	}
+
+	clock.Advance(time.Millisecond)
+
+	batch, _ := testReceive(t, out)
+	if len(batch) != 2 || batch[0] != 1 || batch[1] != 2 {
+		t.Errorf("got %v, want [1 2]", batch)
+	}
+
+	in <- 3
+	clock.WaitTimer(t)
+	close(in)
+
+	batch, _ = testReceive(t, out)
+	if len(batch) != 1 || batch[0] != 3 {
+		t.Errorf("got %v, want [3]", batch)
+	}
+
+	if _, ok := testReceive(t, out); ok {
+		t.Error("channel was not closed")
+	}
+}
+
+func TestChanBatchCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	out := arrays.ChanBatch(ctx, in, 10, time.Hour)
+
+	in <- 1
+	cancel()
+
+	testCollect(t, out)
+}
@@ -0,0 +1,168 @@
+package arrays
+
+import "fmt"
+
+// BiMapPolicy defines how BiMap handles inserts that conflict with existing pairs.
+type BiMapPolicy int
+
+const (
+	// BiMapReject rejects conflicting inserts with ErrKeyCollision.
+	BiMapReject BiMapPolicy = iota
+	// BiMapReplace removes the pairs that conflict with the inserted one.
+	BiMapReplace
+)
+
+// BiMap is a one-to-one map that keeps forward (key to value) and reverse (value to key) indexes in sync.
+// It's not safe for concurrent use.
+type BiMap[I, K comparable] struct {
+	forward map[I]K
+	reverse map[K]I
+	policy  BiMapPolicy
+}
+
+// NewBiMap creates an empty BiMap with the provided conflict policy.
+func NewBiMap[I, K comparable](policy BiMapPolicy) *BiMap[I, K] {
+	return &BiMap[I, K]{
+		forward: make(map[I]K),
+		reverse: make(map[K]I),
+		policy:  policy,
+	}
+}
+
+// NewBiMapFrom creates a BiMap populated with the entries of a plain map.
+// Returns ErrKeyCollision if the policy is BiMapReject and several keys share a value.
+func NewBiMapFrom[I, K comparable](arr map[I]K, policy BiMapPolicy) (*BiMap[I, K], error) {
+	m := NewBiMap[I, K](policy)
+
+	for k, v := range arr {
+		if err := m.Put(k, v); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// Put stores the pair. If the key is already mapped to another value or the value to another key,
+// the insert is rejected with ErrKeyCollision or the old pairs are removed, according to the policy.
+func (m *BiMap[I, K]) Put(key I, value K) error {
+	oldValue, keyExists := m.forward[key]
+	oldKey, valueExists := m.reverse[value]
+
+	if keyExists && valueExists && oldValue == value && oldKey == key {
+		return nil
+	}
+
+	if m.policy == BiMapReject {
+		if keyExists {
+			return fmt.Errorf("key %v is mapped to %v: %w", key, oldValue, ErrKeyCollision)
+		}
+
+		if valueExists {
+			return fmt.Errorf("value %v is mapped to %v: %w", value, oldKey, ErrKeyCollision)
+		}
+	}
+
+	if keyExists {
+		delete(m.reverse, oldValue)
+	}
+
+	if valueExists {
+		delete(m.forward, oldKey)
+	}
+
+	m.forward[key] = value
+	m.reverse[value] = key
+
+	return nil
+}
+
+// Get returns the value for the key and whether it was found.
+func (m *BiMap[I, K]) Get(key I) (K, bool) {
+	v, ok := m.forward[key]
+
+	return v, ok
+}
+
+// GetKey returns the key for the value and whether it was found.
+func (m *BiMap[I, K]) GetKey(value K) (I, bool) {
+	k, ok := m.reverse[value]
+
+	return k, ok
+}
+
+// ContainsKey reports whether the key is present.
+func (m *BiMap[I, K]) ContainsKey(key I) bool {
+	_, ok := m.forward[key]
+
+	return ok
+}
+
+// ContainsValue reports whether the value is present.
+func (m *BiMap[I, K]) ContainsValue(value K) bool {
+	_, ok := m.reverse[value]
+
+	return ok
+}
+
+// DeleteKey removes the pair with the key, returns false if it wasn't found.
+func (m *BiMap[I, K]) DeleteKey(key I) bool {
+	v, ok := m.forward[key]
+	if !ok {
+		return false
+	}
+
+	delete(m.forward, key)
+	delete(m.reverse, v)
+
+	return true
+}
+
+// DeleteValue removes the pair with the value, returns false if it wasn't found.
+func (m *BiMap[I, K]) DeleteValue(value K) bool {
+	k, ok := m.reverse[value]
+	if !ok {
+		return false
+	}
+
+	delete(m.reverse, value)
+	delete(m.forward, k)
+
+	return true
+}
+
+// Len returns the number of pairs.
+func (m *BiMap[I, K]) Len() int {
+	return len(m.forward)
+}
+
+// Keys returns all keys, like MapKeys does for plain maps.
+func (m *BiMap[I, K]) Keys() []I {
+	return MapKeys(m.forward)
+}
+
+// Values returns all values, like MapValues does for plain maps.
+func (m *BiMap[I, K]) Values() []K {
+	return MapValues(m.forward)
+}
+
+// Inverse returns a view of the map with keys and values swapped.
+// The view shares storage with the original map, changes made through one are visible in the other.
+func (m *BiMap[I, K]) Inverse() *BiMap[K, I] {
+	return &BiMap[K, I]{
+		forward: m.reverse,
+		reverse: m.forward,
+		policy:  m.policy,
+	}
+}
+
+// ToMap returns a copy of the forward index as a plain map.
+func (m *BiMap[I, K]) ToMap() map[I]K {
+	r := make(map[I]K, len(m.forward))
+
+	for k, v := range m.forward {
+		r[k] = v
+	}
+
+	return r
+}
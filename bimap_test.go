@@ -0,0 +1,162 @@
+package arrays_test
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+func TestBiMapPut(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		policy    arrays.BiMapPolicy
+		key       string
+		value     int
+		want      map[string]int
+		wantError error
+	}{
+		{
+			name:   "new pair",
+			policy: arrays.BiMapReject,
+			key:    "c",
+			value:  3,
+			want:   map[string]int{"a": 1, "b": 2, "c": 3},
+		},
+		{
+			name:   "existing pair",
+			policy: arrays.BiMapReject,
+			key:    "a",
+			value:  1,
+			want:   map[string]int{"a": 1, "b": 2},
+		},
+		{
+			name:      "reject existing key",
+			policy:    arrays.BiMapReject,
+			key:       "a",
+			value:     3,
+			wantError: arrays.ErrKeyCollision,
+		},
+		{
+			name:      "reject existing value",
+			policy:    arrays.BiMapReject,
+			key:       "c",
+			value:     1,
+			wantError: arrays.ErrKeyCollision,
+		},
+		{
+			name:   "replace existing key",
+			policy: arrays.BiMapReplace,
+			key:    "a",
+			value:  3,
+			want:   map[string]int{"a": 3, "b": 2},
+		},
+		{
+			name:   "replace existing value",
+			policy: arrays.BiMapReplace,
+			key:    "c",
+			value:  1,
+			want:   map[string]int{"c": 1, "b": 2},
+		},
+		{
+			name:   "replace both",
+			policy: arrays.BiMapReplace,
+			key:    "a",
+			value:  2,
+			want:   map[string]int{"a": 2},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := arrays.NewBiMapFrom(map[string]int{"a": 1, "b": 2}, tt.policy)
+			if err != nil {
+				t.Fatalf("NewBiMapFrom() error = %v", err)
+			}
+
+			err = m.Put(tt.key, tt.value)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("Put() error = %v, wantError %v", err, tt.wantError)
+			}
+
+			if tt.wantError != nil {
+				return
+			}
+
+			if got := m.ToMap(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+
+			inverse := m.Inverse().ToMap()
+			if len(inverse) != len(tt.want) {
+				t.Errorf("got inverse %v, want %d pairs", inverse, len(tt.want))
+			}
+
+			for k, v := range tt.want {
+				if inverse[v] != k {
+					t.Errorf("inverse for %v: got %v, want %v", v, inverse[v], k)
+				}
+			}
+		})
+	}
+}
+
+func TestBiMapFromCollision(t *testing.T) {
+	t.Parallel()
+
+	_, err := arrays.NewBiMapFrom(map[string]int{"a": 1, "b": 1}, arrays.BiMapReject)
+	if !errors.Is(err, arrays.ErrKeyCollision) {
+		t.Errorf("got error %v, want %v", err, arrays.ErrKeyCollision)
+	}
+}
+
+func TestBiMapLookupAndDelete(t *testing.T) {
+	t.Parallel()
+
+	m, err := arrays.NewBiMapFrom(map[string]int{"a": 1, "b": 2, "c": 3}, arrays.BiMapReject)
+	if err != nil {
+		t.Fatalf("NewBiMapFrom() error = %v", err)
+	}
+
+	if v, ok := m.Get("b"); !ok || v != 2 {
+		t.Errorf("Get(b) = %v, %v, want 2, true", v, ok)
+	}
+
+	if k, ok := m.GetKey(3); !ok || k != "c" {
+		t.Errorf("GetKey(3) = %v, %v, want c, true", k, ok)
+	}
+
+	if !m.DeleteKey("a") || m.ContainsValue(1) {
+		t.Error("DeleteKey(a) did not remove the value")
+	}
+
+	if !m.DeleteValue(2) || m.ContainsKey("b") {
+		t.Error("DeleteValue(2) did not remove the key")
+	}
+
+	if m.DeleteKey("missing") || m.DeleteValue(100) {
+		t.Error("deleted missing entries")
+	}
+
+	if err := m.Inverse().Put(4, "d"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	if v, ok := m.Get("d"); !ok || v != 4 {
+		t.Errorf("Put through inverse is not visible: %v, %v", v, ok)
+	}
+
+	keys, values := m.Keys(), m.Values()
+	sort.Strings(keys)
+	sort.Ints(values)
+
+	if !reflect.DeepEqual(keys, []string{"c", "d"}) || !reflect.DeepEqual(values, []int{3, 4}) || m.Len() != 2 {
+		t.Errorf("got keys %v, values %v", keys, values)
+	}
+}
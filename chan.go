@@ -0,0 +1,199 @@
+package arrays
+
+import (
+	"context"
+	"sync"
+)
+
+// ChanMap creates a channel populated with the results of calling a provided function
+// on every value received from the input channel.
+// The returned channel is closed when the input channel is closed or the context is done,
+// so consumers stopping early should cancel the context to release the goroutine.
+func ChanMap[I, T any](ctx context.Context, in <-chan I, callback func(value I) T) <-chan T {
+	out := make(chan T)
+
+	go func() {
+		defer close(out)
+
+		for {
+			v, ok := receive(ctx, in)
+			if !ok {
+				return
+			}
+
+			if !send(ctx, out, callback(v)) {
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// ChanFilter creates a channel with just the values received from the input channel
+// that pass the test implemented by the provided function.
+// The returned channel is closed when the input channel is closed or the context is done.
+func ChanFilter[I any](ctx context.Context, in <-chan I, callback func(value I) bool) <-chan I {
+	out := make(chan I)
+
+	go func() {
+		defer close(out)
+
+		for {
+			v, ok := receive(ctx, in)
+			if !ok {
+				return
+			}
+
+			if callback(v) && !send(ctx, out, v) {
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// ChanForEach executes a provided function once for each value received from the input channel.
+// Blocks until the input channel is closed or the context is done, in the latter case returns the context error.
+func ChanForEach[I any](ctx context.Context, in <-chan I, callback func(value I)) error {
+	for {
+		v, ok := receive(ctx, in)
+		if !ok {
+			return ctx.Err()
+		}
+
+		callback(v)
+	}
+}
+
+// FanOut distributes values received from the input channel across n channels in round-robin order,
+// so the k-th value goes to the channel k%n. A slow consumer of one channel blocks the others.
+// All returned channels are closed when the input channel is closed or the context is done.
+// If n is less than 1, a single channel is returned.
+func FanOut[I any](ctx context.Context, in <-chan I, n int) []<-chan I {
+	if n < 1 {
+		n = 1
+	}
+
+	chans := make([]chan I, n)
+	r := make([]<-chan I, n)
+
+	for i := range chans {
+		chans[i] = make(chan I)
+		r[i] = chans[i]
+	}
+
+	go func() {
+		defer func() {
+			for _, c := range chans {
+				close(c)
+			}
+		}()
+
+		for i := 0; ; i = (i + 1) % n {
+			v, ok := receive(ctx, in)
+			if !ok {
+				return
+			}
+
+			if !send(ctx, chans[i], v) {
+				return
+			}
+		}
+	}()
+
+	return r
+}
+
+// FanIn merges values from all provided channels into a single channel in the order they arrive.
+// The returned channel is closed when all provided channels are closed or the context is done.
+func FanIn[I any](ctx context.Context, chans ...<-chan I) <-chan I {
+	out := make(chan I)
+
+	var wg sync.WaitGroup
+
+	wg.Add(len(chans))
+
+	for _, c := range chans {
+		go func(c <-chan I) {
+			defer wg.Done()
+
+			for {
+				v, ok := receive(ctx, c)
+				if !ok {
+					return
+				}
+
+				if !send(ctx, out, v) {
+					return
+				}
+			}
+		}(c)
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// FanInOrdered merges values from all provided channels into a single channel taking them in round-robin order,
+// one value from every channel in turn. Closed channels are skipped.
+// Combined with FanOut and a one-to-one stage such as ChanMap on every channel, it restores the input order.
+// The returned channel is closed when all provided channels are closed or the context is done.
+func FanInOrdered[I any](ctx context.Context, chans ...<-chan I) <-chan I {
+	out := make(chan I)
+
+	go func() {
+		defer close(out)
+
+		open := make([]<-chan I, len(chans))
+		copy(open, chans)
+
+		for i := 0; len(open) > 0; {
+			v, ok := receive(ctx, open[i])
+			if !ok {
+				if ctx.Err() != nil {
+					return
+				}
+
+				open = append(open[:i], open[i+1:]...)
+			} else {
+				if !send(ctx, out, v) {
+					return
+				}
+
+				i++
+			}
+
+			if i >= len(open) {
+				i = 0
+			}
+		}
+	}()
+
+	return out
+}
+
+// receive returns the next value from the channel, false if the channel is closed or the context is done.
+func receive[I any](ctx context.Context, in <-chan I) (I, bool) {
+	select {
+	case <-ctx.Done():
+		return *new(I), false
+	case v, ok := <-in:
+		return v, ok
+	}
+}
+
+// send sends the value to the channel, false if the context is done first.
+func send[I any](ctx context.Context, out chan<- I, v I) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case out <- v:
+		return true
+	}
+}
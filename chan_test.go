@@ -0,0 +1,206 @@
+package arrays_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+func testSource(values ...int) <-chan int {
+	c := make(chan int, len(values))
+	for _, v := range values {
+		c <- v
+	}
+	close(c)
+
+	return c
+}
+
+func testCollect[I any](t *testing.T, c <-chan I) []I {
+	t.Helper()
+
+	r := make([]I, 0)
+	timeout := time.After(5 * time.Second)
+
+	for {
+		select {
+		case v, ok := <-c:
+			if !ok {
+				return r
+			}
+			r = append(r, v)
+		case <-timeout:
+			t.Fatal("channel was not closed")
+		}
+	}
+}
+
+func TestChanMap(t *testing.T) {
+	t.Parallel()
+
+	got := testCollect(t, arrays.ChanMap(context.Background(), testSource(1, 2, 3), func(v int) int {
+		return v * 10
+	}))
+
+	want := []int{10, 20, 30}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	for i, v := range want {
+		if got[i] != v {
+			t.Errorf("got %v, want %v", got[i], v)
+		}
+	}
+}
+
+func TestChanMapCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	out := arrays.ChanMap(ctx, in, func(v int) int { return v })
+
+	go func() {
+		in <- 1
+	}()
+
+	if v := <-out; v != 1 {
+		t.Errorf("got %v, want 1", v)
+	}
+
+	cancel()
+
+	// The consumer stops early, the output channel must still be closed.
+	testCollect(t, out)
+}
+
+func TestChanFilter(t *testing.T) {
+	t.Parallel()
+
+	got := testCollect(t, arrays.ChanFilter(context.Background(), testSource(1, 2, 3, 4, 5), func(v int) bool {
+		return v%2 == 1
+	}))
+
+	want := []int{1, 3, 5}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	for i, v := range want {
+		if got[i] != v {
+			t.Errorf("got %v, want %v", got[i], v)
+		}
+	}
+}
+
+func TestChanForEach(t *testing.T) {
+	t.Parallel()
+
+	sum := 0
+	err := arrays.ChanForEach(context.Background(), testSource(1, 2, 3), func(v int) {
+		sum += v
+	})
+
+	if err != nil {
+		t.Fatalf("ChanForEach() error = %v", err)
+	}
+
+	if sum != 6 {
+		t.Errorf("got %v, want 6", sum)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = arrays.ChanForEach(ctx, make(chan int), func(int) {})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestFanOutFanIn(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	values := []int{1, 2, 3, 4, 5, 6, 7}
+
+	workers := arrays.FanOut(ctx, testSource(values...), 3)
+	if len(workers) != 3 {
+		t.Fatalf("got %d channels, want 3", len(workers))
+	}
+
+	mapped := make([]<-chan int, len(workers))
+	for i, w := range workers {
+		mapped[i] = arrays.ChanMap(ctx, w, func(v int) int { return v * v })
+	}
+
+	got := testCollect(t, arrays.FanIn(ctx, mapped...))
+	sort.Ints(got)
+
+	want := []int{1, 4, 9, 16, 25, 36, 49}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	for i, v := range want {
+		if got[i] != v {
+			t.Errorf("got %v, want %v", got[i], v)
+		}
+	}
+}
+
+func TestFanInOrdered(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	values := []int{1, 2, 3, 4, 5, 6, 7}
+
+	workers := arrays.FanOut(ctx, testSource(values...), 3)
+
+	mapped := make([]<-chan int, len(workers))
+	for i, w := range workers {
+		delay := time.Duration(len(workers)-i) * time.Millisecond
+		mapped[i] = arrays.ChanMap(ctx, w, func(v int) int {
+			time.Sleep(delay)
+			return v * 10
+		})
+	}
+
+	got := testCollect(t, arrays.FanInOrdered(ctx, mapped...))
+
+	want := []int{10, 20, 30, 40, 50, 60, 70}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	for i, v := range want {
+		if got[i] != v {
+			t.Errorf("got %v, want %v", got[i], v)
+		}
+	}
+}
+
+func TestFanInCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	never := make(chan int)
+
+	unordered := arrays.FanIn(ctx, never, never)
+	ordered := arrays.FanInOrdered(ctx, never, never)
+	workers := arrays.FanOut(ctx, never, 2)
+
+	cancel()
+
+	testCollect(t, unordered)
+	testCollect(t, ordered)
+
+	for _, w := range workers {
+		testCollect(t, w)
+	}
+}
@@ -0,0 +1,43 @@
+package arrays
+
+import "time"
+
+// Clock is a source of time used by time-dependent helpers.
+// It can be replaced to make tests deterministic.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single-shot timer created by a Clock.
+type Timer interface {
+	// C returns the channel the current time is sent on when the timer fires.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing, returns false if it has already fired or been stopped.
+	Stop() bool
+}
+
+// SystemClock is a Clock backed by the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	t *time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.t.C
+}
+
+func (t systemTimer) Stop() bool {
+	return t.t.Stop()
+}
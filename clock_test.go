@@ -0,0 +1,115 @@
+package arrays_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+// fakeClock is a manually advanced arrays.Clock for deterministic tests.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	timers  []*fakeTimer
+	created chan struct{}
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{
+		now:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		created: make(chan struct{}, 100),
+	}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) arrays.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTimer{clock: c, deadline: c.now.Add(d), c: make(chan time.Time, 1)}
+	c.timers = append(c.timers, t)
+	c.created <- struct{}{}
+
+	return t
+}
+
+// Advance moves the clock forward and fires all timers with passed deadlines.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.deadline.After(c.now) {
+			pending = append(pending, t)
+			continue
+		}
+		t.c <- c.now
+	}
+	c.timers = pending
+}
+
+// WaitTimer blocks until a new timer is created.
+func (c *fakeClock) WaitTimer(t *testing.T) {
+	t.Helper()
+
+	select {
+	case <-c.created:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timer was not created")
+	}
+}
+
+type fakeTimer struct {
+	clock    *fakeClock
+	deadline time.Time
+	c        chan time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	for i, pending := range t.clock.timers {
+		if pending == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestSystemClock(t *testing.T) {
+	t.Parallel()
+
+	start := arrays.SystemClock.Now()
+	timer := arrays.SystemClock.NewTimer(time.Millisecond)
+
+	select {
+	case fired := <-timer.C():
+		if fired.Before(start) {
+			t.Errorf("timer fired at %v, before %v", fired, start)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timer did not fire")
+	}
+
+	if timer.Stop() {
+		t.Error("Stop() = true for fired timer")
+	}
+}
@@ -0,0 +1,186 @@
+package arrays
+
+import "sort"
+
+// Counter counts occurrences of values, it's a multiset.
+// Only positive counts are stored. It's not safe for concurrent use.
+// The zero value is an empty counter ready to use.
+type Counter[T comparable] struct {
+	counts map[T]int
+	// order keeps the sequence number of the first occurrence of every value to break ties.
+	order map[T]int
+	seq   int
+}
+
+// NewCounter creates a Counter with occurrences of the values of the provided array.
+func NewCounter[T comparable](arr []T) *Counter[T] {
+	c := &Counter[T]{
+		counts: make(map[T]int, len(arr)),
+		order:  make(map[T]int, len(arr)),
+	}
+
+	c.Add(arr...)
+
+	return c
+}
+
+func (c *Counter[T]) addN(value T, n int) {
+	if n == 0 {
+		return
+	}
+
+	if c.counts == nil {
+		c.counts = make(map[T]int)
+		c.order = make(map[T]int)
+	}
+
+	if _, ok := c.order[value]; !ok {
+		c.order[value] = c.seq
+		c.seq++
+	}
+
+	c.counts[value] += n
+
+	if c.counts[value] <= 0 {
+		delete(c.counts, value)
+		delete(c.order, value)
+	}
+}
+
+// Add increments the count of every value by one.
+func (c *Counter[T]) Add(values ...T) {
+	for _, v := range values {
+		c.addN(v, 1)
+	}
+}
+
+// Subtract decrements the count of every value by one, values with no occurrences left are removed.
+func (c *Counter[T]) Subtract(values ...T) {
+	for _, v := range values {
+		if c.counts[v] > 0 {
+			c.addN(v, -1)
+		}
+	}
+}
+
+// Count returns the number of occurrences of the value.
+func (c *Counter[T]) Count(value T) int {
+	return c.counts[value]
+}
+
+// Len returns the number of distinct values.
+func (c *Counter[T]) Len() int {
+	return len(c.counts)
+}
+
+// Total returns the sum of all counts.
+func (c *Counter[T]) Total() int {
+	total := 0
+
+	for _, n := range c.counts {
+		total += n
+	}
+
+	return total
+}
+
+// values returns distinct values in order of their first occurrence.
+func (c *Counter[T]) values() []T {
+	r := MapKeys(c.counts)
+
+	sort.Slice(r, func(i, j int) bool {
+		return c.order[r[i]] < c.order[r[j]]
+	})
+
+	return r
+}
+
+// MostCommon returns up to n values with the highest counts in descending order of count.
+// Values with equal counts are ordered by their first occurrence. If n is not positive, all values are returned.
+func (c *Counter[T]) MostCommon(n int) []Pair[T, int] {
+	values := c.values()
+
+	sort.SliceStable(values, func(i, j int) bool {
+		return c.counts[values[i]] > c.counts[values[j]]
+	})
+
+	if n > 0 && n < len(values) {
+		values = values[:n]
+	}
+
+	return ArrayProcess(values, func(v T) Pair[T, int] {
+		return Pair[T, int]{Key: v, Value: c.counts[v]}
+	})
+}
+
+// ToMap returns a copy of the counts as a plain map.
+func (c *Counter[T]) ToMap() map[T]int {
+	return MapFilter(c.counts, func(T, int) bool {
+		return true
+	})
+}
+
+// combine creates a new counter with count(v) = callback(c.Count(v), other.Count(v)) for every value of both counters.
+func (c *Counter[T]) combine(other *Counter[T], callback func(a, b int) int) *Counter[T] {
+	r := NewCounter[T](nil)
+
+	for _, v := range ArrayConcat(c.values(), other.values()) {
+		if _, ok := r.order[v]; ok {
+			continue
+		}
+
+		r.addN(v, callback(c.counts[v], other.counts[v]))
+	}
+
+	return r
+}
+
+// Sum creates a new counter with counts of both counters added.
+func (c *Counter[T]) Sum(other *Counter[T]) *Counter[T] {
+	return c.combine(other, func(a, b int) int {
+		return a + b
+	})
+}
+
+// Difference creates a new counter with counts of the other counter subtracted, keeping only positive counts.
+func (c *Counter[T]) Difference(other *Counter[T]) *Counter[T] {
+	return c.combine(other, func(a, b int) int {
+		return a - b
+	})
+}
+
+// Intersection creates a new counter with the minimum of counts of both counters.
+func (c *Counter[T]) Intersection(other *Counter[T]) *Counter[T] {
+	return c.combine(other, func(a, b int) int {
+		if a < b {
+			return a
+		}
+
+		return b
+	})
+}
+
+// Equal reports whether both counters hold the same values with the same counts.
+func (c *Counter[T]) Equal(other *Counter[T]) bool {
+	if len(c.counts) != len(other.counts) {
+		return false
+	}
+
+	for v, n := range c.counts {
+		if other.counts[v] != n {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ArrayMultisetEqual reports whether both arrays contain the same elements the same number of times,
+// regardless of order.
+func ArrayMultisetEqual[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	return NewCounter(a).Equal(NewCounter(b))
+}
@@ -0,0 +1,187 @@
+package arrays_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+func TestCounter(t *testing.T) {
+	t.Parallel()
+
+	c := arrays.NewCounter(strings.Split("abracadabra", ""))
+
+	if c.Count("a") != 5 || c.Count("z") != 0 {
+		t.Errorf("Count(a) = %d, Count(z) = %d, want 5, 0", c.Count("a"), c.Count("z"))
+	}
+
+	if c.Len() != 5 || c.Total() != 11 {
+		t.Errorf("Len() = %d, Total() = %d, want 5, 11", c.Len(), c.Total())
+	}
+
+	c.Add("z", "z")
+	c.Subtract("a", "c", "missing")
+
+	want := map[string]int{"a": 4, "b": 2, "r": 2, "d": 1, "z": 2}
+	if got := c.ToMap(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestCounterZeroValue(t *testing.T) {
+	t.Parallel()
+
+	var c arrays.Counter[string]
+
+	if c.Count("a") != 0 || c.Len() != 0 || len(c.MostCommon(1)) != 0 {
+		t.Error("zero counter is not empty")
+	}
+
+	c.Subtract("a")
+	c.Add("a", "b", "a")
+
+	if got := c.ToMap(); !reflect.DeepEqual(got, map[string]int{"a": 2, "b": 1}) {
+		t.Errorf("got %v, want map[a:2 b:1]", got)
+	}
+
+	if !c.Equal(arrays.NewCounter([]string{"b", "a", "a"})) {
+		t.Error("Equal() = false")
+	}
+}
+
+func TestCounterMostCommon(t *testing.T) {
+	t.Parallel()
+
+	c := arrays.NewCounter(strings.Split("abracadabra", ""))
+
+	tests := []struct {
+		name string
+		n    int
+		want []arrays.Pair[string, int]
+	}{
+		{
+			name: "top two with ties broken by first occurrence",
+			n:    2,
+			want: []arrays.Pair[string, int]{{Key: "a", Value: 5}, {Key: "b", Value: 2}},
+		},
+		{
+			name: "all values",
+			n:    0,
+			want: []arrays.Pair[string, int]{
+				{Key: "a", Value: 5},
+				{Key: "b", Value: 2},
+				{Key: "r", Value: 2},
+				{Key: "c", Value: 1},
+				{Key: "d", Value: 1},
+			},
+		},
+		{
+			name: "more than available",
+			n:    10,
+			want: []arrays.Pair[string, int]{
+				{Key: "a", Value: 5},
+				{Key: "b", Value: 2},
+				{Key: "r", Value: 2},
+				{Key: "c", Value: 1},
+				{Key: "d", Value: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := c.MostCommon(tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCounterArithmetic(t *testing.T) {
+	t.Parallel()
+
+	a := arrays.NewCounter([]string{"x", "x", "x", "y"})
+	b := arrays.NewCounter([]string{"x", "y", "y", "z"})
+
+	tests := []struct {
+		name string
+		got  *arrays.Counter[string]
+		want map[string]int
+	}{
+		{
+			name: "sum",
+			got:  a.Sum(b),
+			want: map[string]int{"x": 4, "y": 3, "z": 1},
+		},
+		{
+			name: "difference",
+			got:  a.Difference(b),
+			want: map[string]int{"x": 2},
+		},
+		{
+			name: "intersection",
+			got:  a.Intersection(b),
+			want: map[string]int{"x": 1, "y": 1},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.got.ToMap(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArrayMultisetEqual(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		a    []int
+		b    []int
+		want bool
+	}{
+		{
+			name: "same elements in different order",
+			a:    []int{1, 2, 2, 3},
+			b:    []int{2, 3, 1, 2},
+			want: true,
+		},
+		{
+			name: "different counts",
+			a:    []int{1, 1, 2},
+			b:    []int{1, 2, 2},
+			want: false,
+		},
+		{
+			name: "different lengths",
+			a:    []int{1, 2},
+			b:    []int{1, 2, 2},
+			want: false,
+		},
+		{
+			name: "empty arrays",
+			a:    []int{},
+			b:    nil,
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := arrays.ArrayMultisetEqual(tt.a, tt.b); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
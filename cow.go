@@ -0,0 +1,155 @@
+package arrays
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// COWSlice is a copy-on-write slice for data that is read often and updated rarely.
+// Readers get an immutable snapshot with a single atomic load and never block,
+// writers are serialized and publish a new version of the slice.
+// The zero value is an empty slice ready to use.
+type COWSlice[T any] struct {
+	mu      sync.Mutex
+	current atomic.Value // *cowVersion[T]
+	subs    map[*cowSubscriber[T]]struct{}
+}
+
+type cowVersion[T any] struct {
+	arr     []T
+	version uint64
+}
+
+type cowSubscriber[T any] struct {
+	c chan []T
+}
+
+// NewCOWSlice creates a new COWSlice holding a copy of the provided array.
+func NewCOWSlice[T any](arr []T) *COWSlice[T] {
+	s := &COWSlice[T]{}
+	s.current.Store(&cowVersion[T]{arr: cowCopy(arr)})
+
+	return s
+}
+
+// cowCopy copies the array with capacity equal to length, so appending to a snapshot never touches shared memory.
+func cowCopy[T any](arr []T) []T {
+	r := make([]T, len(arr))
+	copy(r, arr)
+
+	return r
+}
+
+func (s *COWSlice[T]) load() *cowVersion[T] {
+	if v, ok := s.current.Load().(*cowVersion[T]); ok {
+		return v
+	}
+
+	return &cowVersion[T]{arr: []T{}}
+}
+
+// Load returns the current snapshot. The snapshot must not be modified.
+func (s *COWSlice[T]) Load() []T {
+	return s.load().arr
+}
+
+// Version returns the number of updates applied since the slice was created.
+func (s *COWSlice[T]) Version() uint64 {
+	return s.load().version
+}
+
+// Len returns the length of the current snapshot.
+func (s *COWSlice[T]) Len() int {
+	return len(s.load().arr)
+}
+
+// Store replaces the contents with a copy of the provided array.
+func (s *COWSlice[T]) Store(arr []T) {
+	s.Update(func([]T) []T {
+		return arr
+	})
+}
+
+// Update replaces the contents with the result of calling a provided function on the current snapshot.
+// The function must not modify its argument, functions like ArrayFilter and ArrayMap are safe to use.
+// The result is copied, so it may share memory with the caller. Returns the new snapshot.
+func (s *COWSlice[T]) Update(callback func(arr []T) []T) []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cur := s.load()
+	next := &cowVersion[T]{arr: cowCopy(callback(cur.arr)), version: cur.version + 1}
+	s.current.Store(next)
+
+	for sub := range s.subs {
+		sub.notify(next.arr)
+	}
+
+	return next.arr
+}
+
+// Append adds values to the end of the slice. Returns the new snapshot.
+func (s *COWSlice[T]) Append(values ...T) []T {
+	return s.Update(func(arr []T) []T {
+		return ArrayConcat(arr, values)
+	})
+}
+
+// Filter keeps only the elements that pass the test implemented by the provided function.
+// Returns the new snapshot.
+func (s *COWSlice[T]) Filter(callback func(key int, value T) bool) []T {
+	return s.Update(func(arr []T) []T {
+		return ArrayFilter(arr, callback)
+	})
+}
+
+// Map replaces every element with the result of calling a provided function on it.
+// Returns the new snapshot.
+func (s *COWSlice[T]) Map(callback func(key int, value T) T) []T {
+	return s.Update(func(arr []T) []T {
+		return ArrayMap(arr, callback)
+	})
+}
+
+// Subscribe returns a channel receiving a snapshot after every update and a function to cancel the subscription.
+// Slow subscribers only receive the latest snapshot, intermediate ones are dropped.
+// The channel is closed when the subscription is cancelled.
+func (s *COWSlice[T]) Subscribe() (<-chan []T, func()) {
+	sub := &cowSubscriber[T]{c: make(chan []T, 1)}
+
+	s.mu.Lock()
+	if s.subs == nil {
+		s.subs = make(map[*cowSubscriber[T]]struct{})
+	}
+
+	s.subs[sub] = struct{}{}
+	s.mu.Unlock()
+
+	var once sync.Once
+
+	return sub.c, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			delete(s.subs, sub)
+			close(sub.c)
+		})
+	}
+}
+
+// notify sends the snapshot without blocking, replacing a pending one.
+func (sub *cowSubscriber[T]) notify(arr []T) {
+	for {
+		select {
+		case sub.c <- arr:
+			return
+		default:
+		}
+
+		select {
+		case <-sub.c:
+		default:
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package arrays_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+func TestCOWSlice(t *testing.T) {
+	t.Parallel()
+
+	src := []int{1, 2, 3}
+	s := arrays.NewCOWSlice(src)
+	src[0] = 100
+
+	snapshot := s.Load()
+	if len(snapshot) != 3 || snapshot[0] != 1 {
+		t.Fatalf("got %v, want [1 2 3]", snapshot)
+	}
+
+	s.Append(4, 5)
+	s.Filter(func(_ int, v int) bool { return v%2 == 1 })
+	s.Map(func(_ int, v int) int { return v * 10 })
+
+	want := []int{10, 30, 50}
+	got := s.Load()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	for i, v := range want {
+		if got[i] != v {
+			t.Errorf("got %v, want %v", got[i], v)
+		}
+	}
+
+	if len(snapshot) != 3 || snapshot[0] != 1 || snapshot[2] != 3 {
+		t.Errorf("old snapshot was modified: %v", snapshot)
+	}
+
+	if s.Version() != 3 || s.Len() != 3 {
+		t.Errorf("got version %d, length %d, want 3, 3", s.Version(), s.Len())
+	}
+}
+
+func TestCOWSliceAppendDoesNotShareMemory(t *testing.T) {
+	t.Parallel()
+
+	s := arrays.NewCOWSlice([]int{1, 2})
+	snapshot := s.Load()
+
+	s.Update(func(arr []int) []int {
+		return append(arr, 3)
+	})
+
+	if extended := append(snapshot, 4); s.Load()[2] != 3 || extended[2] != 4 {
+		t.Errorf("snapshots share memory: %v, %v", s.Load(), extended)
+	}
+}
+
+func TestCOWSliceZeroValue(t *testing.T) {
+	t.Parallel()
+
+	var s arrays.COWSlice[string]
+
+	if s.Len() != 0 || s.Load() == nil {
+		t.Errorf("got %v, want empty slice", s.Load())
+	}
+
+	s.Store([]string{"a"})
+
+	if got := s.Load(); len(got) != 1 || got[0] != "a" {
+		t.Errorf("got %v, want [a]", got)
+	}
+}
+
+func TestCOWSliceSubscribe(t *testing.T) {
+	t.Parallel()
+
+	s := arrays.NewCOWSlice([]int{})
+	updates, cancel := s.Subscribe()
+
+	s.Append(1)
+	s.Append(2)
+
+	select {
+	case got := <-updates:
+		if len(got) != 2 {
+			t.Errorf("got %v, want the latest snapshot [1 2]", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no update received")
+	}
+
+	cancel()
+	cancel()
+
+	s.Append(3)
+
+	if _, ok := <-updates; ok {
+		t.Error("channel was not closed")
+	}
+}
+
+func TestCOWSliceConcurrent(t *testing.T) {
+	t.Parallel()
+
+	s := arrays.NewCOWSlice([]int{})
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+			s.Append(i)
+		}(i)
+
+		go func() {
+			defer wg.Done()
+
+			for _, v := range s.Load() {
+				_ = v
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if s.Len() != 10 || s.Version() != 10 {
+		t.Errorf("got length %d, version %d, want 10, 10", s.Len(), s.Version())
+	}
+}
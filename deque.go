@@ -0,0 +1,257 @@
+package arrays
+
+const minDequeCapacity = 8
+
+// Deque is a double-ended queue backed by a growable ring buffer.
+// Pushes and pops at both ends and index access take O(1).
+// The zero value is an empty deque ready to use. It's not safe for concurrent use.
+type Deque[T any] struct {
+	buf  []T
+	head int
+	size int
+}
+
+// NewDeque creates a deque with the values of the provided array, the first element on the front.
+func NewDeque[T any](arr []T) *Deque[T] {
+	d := &Deque[T]{}
+	d.grow(len(arr))
+
+	for _, v := range arr {
+		d.PushBack(v)
+	}
+
+	return d
+}
+
+// Len returns the number of elements.
+func (d *Deque[T]) Len() int {
+	return d.size
+}
+
+// grow makes room for n more elements.
+func (d *Deque[T]) grow(n int) {
+	if d.size+n <= len(d.buf) {
+		return
+	}
+
+	capacity := len(d.buf) * 2
+	if capacity < minDequeCapacity {
+		capacity = minDequeCapacity
+	}
+
+	for capacity < d.size+n {
+		capacity *= 2
+	}
+
+	buf := make([]T, capacity)
+	d.copyTo(buf)
+	d.buf, d.head = buf, 0
+}
+
+// shrink halves the buffer when it's mostly empty, so a drained deque doesn't hold memory.
+func (d *Deque[T]) shrink() {
+	if len(d.buf) > minDequeCapacity && d.size <= len(d.buf)/4 {
+		buf := make([]T, len(d.buf)/2)
+		d.copyTo(buf)
+		d.buf, d.head = buf, 0
+	}
+}
+
+func (d *Deque[T]) copyTo(dst []T) {
+	if d.size == 0 {
+		return
+	}
+
+	if end := d.head + d.size; end <= len(d.buf) {
+		copy(dst, d.buf[d.head:end])
+
+		return
+	}
+
+	n := copy(dst, d.buf[d.head:])
+	copy(dst[n:], d.buf[:d.size-n])
+}
+
+func (d *Deque[T]) pos(i int) int {
+	return (d.head + i) % len(d.buf)
+}
+
+// PushBack adds values to the back in order.
+func (d *Deque[T]) PushBack(values ...T) {
+	d.grow(len(values))
+
+	for _, v := range values {
+		d.buf[d.pos(d.size)] = v
+		d.size++
+	}
+}
+
+// PushFront adds the value to the front.
+func (d *Deque[T]) PushFront(value T) {
+	d.grow(1)
+
+	d.head = (d.head - 1 + len(d.buf)) % len(d.buf)
+	d.buf[d.head] = value
+	d.size++
+}
+
+// PopFront removes and returns the front element, false if the deque is empty.
+func (d *Deque[T]) PopFront() (T, bool) {
+	if d.size == 0 {
+		return *new(T), false
+	}
+
+	v := d.buf[d.head]
+	d.buf[d.head] = *new(T)
+	d.head = d.pos(1)
+	d.size--
+	d.shrink()
+
+	return v, true
+}
+
+// PopBack removes and returns the back element, false if the deque is empty.
+func (d *Deque[T]) PopBack() (T, bool) {
+	if d.size == 0 {
+		return *new(T), false
+	}
+
+	i := d.pos(d.size - 1)
+	v := d.buf[i]
+	d.buf[i] = *new(T)
+	d.size--
+	d.shrink()
+
+	return v, true
+}
+
+// Front returns the front element without removing it, false if the deque is empty.
+func (d *Deque[T]) Front() (T, bool) {
+	return d.At(0)
+}
+
+// Back returns the back element without removing it, false if the deque is empty.
+func (d *Deque[T]) Back() (T, bool) {
+	return d.At(d.size - 1)
+}
+
+// At returns the element at the index counted from the front, false if the index is out of range.
+func (d *Deque[T]) At(i int) (T, bool) {
+	if i < 0 || i >= d.size {
+		return *new(T), false
+	}
+
+	return d.buf[d.pos(i)], true
+}
+
+// Set replaces the element at the index counted from the front, false if the index is out of range.
+func (d *Deque[T]) Set(i int, value T) bool {
+	if i < 0 || i >= d.size {
+		return false
+	}
+
+	d.buf[d.pos(i)] = value
+
+	return true
+}
+
+// Clear removes all elements.
+func (d *Deque[T]) Clear() {
+	*d = Deque[T]{}
+}
+
+// ToSlice returns a copy of the elements from the front to the back.
+func (d *Deque[T]) ToSlice() []T {
+	r := make([]T, d.size)
+	d.copyTo(r)
+
+	return r
+}
+
+// Ring is a fixed-capacity circular buffer that overwrites the oldest element when it's full.
+// It's not safe for concurrent use. Unlike Deque, the zero value has no capacity and is not usable,
+// create rings with NewRing.
+type Ring[T any] struct {
+	buf  []T
+	head int
+	size int
+}
+
+// NewRing creates an empty ring holding at most capacity elements, at least one.
+func NewRing[T any](capacity int) *Ring[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &Ring[T]{buf: make([]T, capacity)}
+}
+
+// Len returns the number of elements.
+func (r *Ring[T]) Len() int {
+	return r.size
+}
+
+// Cap returns the capacity of the ring.
+func (r *Ring[T]) Cap() int {
+	return len(r.buf)
+}
+
+// Full reports whether the next push overwrites the oldest element.
+func (r *Ring[T]) Full() bool {
+	return r.size == len(r.buf)
+}
+
+// Push adds values in order, overwriting the oldest elements when the ring is full.
+// Returns the number of overwritten elements.
+func (r *Ring[T]) Push(values ...T) int {
+	overwritten := 0
+
+	for _, v := range values {
+		if r.Full() {
+			r.buf[r.head] = v
+			r.head = (r.head + 1) % len(r.buf)
+			overwritten++
+
+			continue
+		}
+
+		r.buf[(r.head+r.size)%len(r.buf)] = v
+		r.size++
+	}
+
+	return overwritten
+}
+
+// Pop removes and returns the oldest element, false if the ring is empty.
+func (r *Ring[T]) Pop() (T, bool) {
+	if r.size == 0 {
+		return *new(T), false
+	}
+
+	v := r.buf[r.head]
+	r.buf[r.head] = *new(T)
+	r.head = (r.head + 1) % len(r.buf)
+	r.size--
+
+	return v, true
+}
+
+// At returns the element at the index counted from the oldest one, false if the index is out of range.
+func (r *Ring[T]) At(i int) (T, bool) {
+	if i < 0 || i >= r.size {
+		return *new(T), false
+	}
+
+	return r.buf[(r.head+i)%len(r.buf)], true
+}
+
+// ToSlice returns a copy of the elements from the oldest to the newest.
+func (r *Ring[T]) ToSlice() []T {
+	res := make([]T, r.size)
+
+	for i := range res {
+		res[i] = r.buf[(r.head+i)%len(r.buf)]
+	}
+
+	return res
+}
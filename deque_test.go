@@ -0,0 +1,146 @@
+package arrays_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+func TestDeque(t *testing.T) {
+	t.Parallel()
+
+	var d arrays.Deque[int]
+
+	d.PushBack(3, 4, 5)
+	d.PushFront(2)
+	d.PushFront(1)
+
+	if got := d.ToSlice(); !reflect.DeepEqual(got, []int{1, 2, 3, 4, 5}) {
+		t.Fatalf("got %v, want [1 2 3 4 5]", got)
+	}
+
+	if v, ok := d.At(2); !ok || v != 3 {
+		t.Errorf("At(2) = %v, %v, want 3, true", v, ok)
+	}
+
+	if _, ok := d.At(5); ok {
+		t.Error("At(5) returned a value")
+	}
+
+	if !d.Set(0, 10) {
+		t.Error("Set(0) = false")
+	}
+
+	if v, ok := d.Front(); !ok || v != 10 {
+		t.Errorf("Front() = %v, %v, want 10, true", v, ok)
+	}
+
+	if v, ok := d.Back(); !ok || v != 5 {
+		t.Errorf("Back() = %v, %v, want 5, true", v, ok)
+	}
+
+	if v, ok := d.PopFront(); !ok || v != 10 {
+		t.Errorf("PopFront() = %v, %v, want 10, true", v, ok)
+	}
+
+	if v, ok := d.PopBack(); !ok || v != 5 {
+		t.Errorf("PopBack() = %v, %v, want 5, true", v, ok)
+	}
+
+	doubled := arrays.ArrayMap(d.ToSlice(), func(_ int, v int) int { return v * 2 })
+	if !reflect.DeepEqual(doubled, []int{4, 6, 8}) {
+		t.Errorf("got %v, want [4 6 8]", doubled)
+	}
+
+	d.Clear()
+
+	if _, ok := d.PopFront(); ok || d.Len() != 0 {
+		t.Error("Clear() did not empty the deque")
+	}
+
+	if _, ok := d.PopBack(); ok {
+		t.Error("PopBack() on empty deque returned a value")
+	}
+}
+
+func TestDequeGrowAndWrap(t *testing.T) {
+	t.Parallel()
+
+	d := arrays.NewDeque([]int{})
+	want := make([]int, 0)
+
+	// Mix operations at both ends so the buffer wraps around and resizes several times.
+	for i := 0; i < 1000; i++ {
+		switch i % 5 {
+		case 0, 1:
+			d.PushBack(i)
+			want = append(want, i)
+		case 2:
+			d.PushFront(i)
+			want = append([]int{i}, want...)
+		case 3:
+			v, _ := d.PopFront()
+			if v != want[0] {
+				t.Fatalf("PopFront() = %v, want %v", v, want[0])
+			}
+			want = want[1:]
+		}
+	}
+
+	if got := d.ToSlice(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	for d.Len() > 0 {
+		v, _ := d.PopBack()
+		if v != want[len(want)-1] {
+			t.Fatalf("PopBack() = %v, want %v", v, want[len(want)-1])
+		}
+		want = want[:len(want)-1]
+	}
+}
+
+func TestRing(t *testing.T) {
+	t.Parallel()
+
+	r := arrays.NewRing[int](3)
+
+	if n := r.Push(1, 2); n != 0 || r.Full() {
+		t.Errorf("Push() overwrote %d, Full() = %v, want 0, false", n, r.Full())
+	}
+
+	if n := r.Push(3, 4, 5); n != 2 || !r.Full() {
+		t.Errorf("Push() overwrote %d, Full() = %v, want 2, true", n, r.Full())
+	}
+
+	if got := r.ToSlice(); !reflect.DeepEqual(got, []int{3, 4, 5}) {
+		t.Errorf("got %v, want [3 4 5]", got)
+	}
+
+	if v, ok := r.At(1); !ok || v != 4 {
+		t.Errorf("At(1) = %v, %v, want 4, true", v, ok)
+	}
+
+	if v, ok := r.Pop(); !ok || v != 3 {
+		t.Errorf("Pop() = %v, %v, want 3, true", v, ok)
+	}
+
+	r.Push(6)
+
+	odd := arrays.ArrayFilter(r.ToSlice(), func(_ int, v int) bool { return v%2 == 1 })
+	if !reflect.DeepEqual(odd, []int{5}) {
+		t.Errorf("got %v, want [5]", odd)
+	}
+
+	if r.Len() != 3 || r.Cap() != 3 {
+		t.Errorf("Len() = %d, Cap() = %d, want 3, 3", r.Len(), r.Cap())
+	}
+
+	empty := arrays.NewRing[int](0)
+	empty.Push(1, 2)
+
+	if got := empty.ToSlice(); !reflect.DeepEqual(got, []int{2}) {
+		t.Errorf("got %v, want [2]", got)
+	}
+}
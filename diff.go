@@ -0,0 +1,238 @@
+package arrays
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPatchMismatch is returned by ArrayPatch when the edit script doesn't match the provided array.
+var ErrPatchMismatch = errors.New("edit script does not match array")
+
+// EditOp is a kind of operation in an edit script.
+type EditOp int
+
+const (
+	// EditKeep keeps an element of the old array.
+	EditKeep EditOp = iota
+	// EditInsert inserts an element of the new array.
+	EditInsert
+	// EditDelete deletes an element of the old array.
+	EditDelete
+)
+
+// String returns the name of the operation.
+func (op EditOp) String() string {
+	switch op {
+	case EditKeep:
+		return "keep"
+	case EditInsert:
+		return "insert"
+	case EditDelete:
+		return "delete"
+	default:
+		return fmt.Sprintf("EditOp(%d)", int(op))
+	}
+}
+
+// Edit is a single step of an edit script produced by ArrayDiff.
+// OldIndex and NewIndex are the positions in the old and the new array the step applies to.
+// For EditInsert OldIndex is the position in the old array the value is inserted before,
+// for EditDelete NewIndex is the position in the new array the value is deleted before.
+type Edit[I any] struct {
+	Op       EditOp
+	OldIndex int
+	NewIndex int
+	Value    I
+}
+
+// ArrayDiff returns a minimal edit script turning the old array into the new one.
+// The script is computed with the Myers algorithm and lists every element of both arrays in order.
+// For non-comparable values use ArrayDiffBy.
+func ArrayDiff[I comparable](oldArr, newArr []I) []Edit[I] {
+	return ArrayDiffBy(oldArr, newArr, func(a, b I) bool {
+		return a == b
+	})
+}
+
+// ArrayDiffBy returns a minimal edit script turning the old array into the new one.
+// Elements are compared with the provided equal function.
+// Uses the linear space refinement of the Myers algorithm, memory is O(n+m).
+func ArrayDiffBy[I any](oldArr, newArr []I, equal func(a, b I) bool) []Edit[I] {
+	size := len(oldArr) + len(newArr) + 3
+
+	d := &differ[I]{
+		oldArr: oldArr,
+		newArr: newArr,
+		equal:  equal,
+		fwd:    make([]int, size),
+		rev:    make([]int, size),
+		r:      make([]Edit[I], 0, len(oldArr)+len(newArr)),
+	}
+
+	d.diff(0, len(oldArr), 0, len(newArr))
+
+	return d.r
+}
+
+// differ holds the state of a single ArrayDiffBy call.
+// The fwd and rev buffers are shared by all bisect calls, which never overlap in time.
+type differ[I any] struct {
+	oldArr, newArr []I
+	equal          func(a, b I) bool
+	fwd, rev       []int
+	r              []Edit[I]
+}
+
+// diff appends the edit script for oldArr[oldLo:oldHi] and newArr[newLo:newHi].
+func (d *differ[I]) diff(oldLo, oldHi, newLo, newHi int) {
+	for oldLo < oldHi && newLo < newHi && d.equal(d.oldArr[oldLo], d.newArr[newLo]) {
+		d.r = append(d.r, Edit[I]{Op: EditKeep, OldIndex: oldLo, NewIndex: newLo, Value: d.oldArr[oldLo]})
+		oldLo++
+		newLo++
+	}
+
+	suffix := 0
+	for oldHi-suffix > oldLo && newHi-suffix > newLo &&
+		d.equal(d.oldArr[oldHi-suffix-1], d.newArr[newHi-suffix-1]) {
+		suffix++
+	}
+
+	oldHi -= suffix
+	newHi -= suffix
+
+	switch {
+	case oldLo == oldHi:
+		for y := newLo; y < newHi; y++ {
+			d.r = append(d.r, Edit[I]{Op: EditInsert, OldIndex: oldLo, NewIndex: y, Value: d.newArr[y]})
+		}
+	case newLo == newHi:
+		for x := oldLo; x < oldHi; x++ {
+			d.r = append(d.r, Edit[I]{Op: EditDelete, OldIndex: x, NewIndex: newLo, Value: d.oldArr[x]})
+		}
+	default:
+		x, y := d.bisect(oldLo, oldHi, newLo, newHi)
+		d.diff(oldLo, x, newLo, y)
+		d.diff(x, oldHi, y, newHi)
+	}
+
+	for i := 0; i < suffix; i++ {
+		d.r = append(d.r, Edit[I]{Op: EditKeep, OldIndex: oldHi + i, NewIndex: newHi + i, Value: d.oldArr[oldHi+i]})
+	}
+}
+
+// bisect finds the middle snake of an optimal path through oldArr[oldLo:oldHi] and newArr[newLo:newHi]
+// by running the forward and the reverse searches until they meet, and returns the point to split at.
+func (d *differ[I]) bisect(oldLo, oldHi, newLo, newHi int) (int, int) {
+	n, m := oldHi-oldLo, newHi-newLo
+	maxD := (n + m + 1) / 2
+	offset := maxD
+	fwd, rev := d.fwd[:2*maxD+2], d.rev[:2*maxD+2]
+
+	for i := range fwd {
+		fwd[i], rev[i] = -1, -1
+	}
+
+	fwd[offset+1], rev[offset+1] = 0, 0
+
+	delta := n - m
+	front := delta%2 != 0
+
+	// The start and end trims skip diagonals that have run off the edit graph.
+	var fwdStart, fwdEnd, revStart, revEnd int
+
+	for D := 0; D < maxD; D++ {
+		for k := -D + fwdStart; k <= D-fwdEnd; k += 2 {
+			var x int
+			if k == -D || (k != D && fwd[offset+k-1] < fwd[offset+k+1]) {
+				x = fwd[offset+k+1]
+			} else {
+				x = fwd[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && d.equal(d.oldArr[oldLo+x], d.newArr[newLo+y]) {
+				x++
+				y++
+			}
+
+			fwd[offset+k] = x
+
+			switch {
+			case x > n:
+				fwdEnd += 2
+			case y > m:
+				fwdStart += 2
+			case front:
+				if rk := offset + delta - k; rk >= 0 && rk < len(rev) && rev[rk] != -1 && x >= n-rev[rk] {
+					return oldLo + x, newLo + y
+				}
+			}
+		}
+
+		for k := -D + revStart; k <= D-revEnd; k += 2 {
+			var x int
+			if k == -D || (k != D && rev[offset+k-1] < rev[offset+k+1]) {
+				x = rev[offset+k+1]
+			} else {
+				x = rev[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && d.equal(d.oldArr[oldHi-x-1], d.newArr[newHi-y-1]) {
+				x++
+				y++
+			}
+
+			rev[offset+k] = x
+
+			switch {
+			case x > n:
+				revEnd += 2
+			case y > m:
+				revStart += 2
+			case !front:
+				if fk := offset + delta - k; fk >= 0 && fk < len(fwd) && fwd[fk] != -1 && fwd[fk] >= n-x {
+					fx := fwd[fk]
+
+					return oldLo + fx, newLo + fx - (fk - offset)
+				}
+			}
+		}
+	}
+
+	// Unreachable for a finite input, the searches always meet by maxD.
+	return oldLo + n, newLo
+}
+
+// ArrayPatch creates a new array by applying the edit script to the provided array.
+// Returns ErrPatchMismatch if the script doesn't cover the array exactly.
+func ArrayPatch[I any](arr []I, script []Edit[I]) ([]I, error) {
+	r := make([]I, 0, len(arr))
+	pos := 0
+
+	for i, edit := range script {
+		switch edit.Op {
+		case EditKeep, EditDelete:
+			if edit.OldIndex != pos || pos >= len(arr) {
+				return nil, fmt.Errorf("edit %d: %w: %s at old index %d, expected %d",
+					i, ErrPatchMismatch, edit.Op, edit.OldIndex, pos)
+			}
+
+			if edit.Op == EditKeep {
+				r = append(r, arr[pos])
+			}
+
+			pos++
+		case EditInsert:
+			r = append(r, edit.Value)
+		default:
+			return nil, fmt.Errorf("edit %d: %w: unknown operation %s", i, ErrPatchMismatch, edit.Op)
+		}
+	}
+
+	if pos != len(arr) {
+		return nil, fmt.Errorf("%w: %d elements not covered", ErrPatchMismatch, len(arr)-pos)
+	}
+
+	return r, nil
+}
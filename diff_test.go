@@ -0,0 +1,329 @@
+package arrays_test
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+func TestArrayDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		oldArr    []string
+		newArr    []string
+		wantEdits int
+	}{
+		{
+			name:      "classic example",
+			oldArr:    strings.Split("ABCABBA", ""),
+			newArr:    strings.Split("CBABAC", ""),
+			wantEdits: 5,
+		},
+		{
+			name:      "equal arrays",
+			oldArr:    []string{"a", "b", "c"},
+			newArr:    []string{"a", "b", "c"},
+			wantEdits: 0,
+		},
+		{
+			name:      "only inserts",
+			oldArr:    []string{},
+			newArr:    []string{"a", "b"},
+			wantEdits: 2,
+		},
+		{
+			name:      "only deletes",
+			oldArr:    []string{"a", "b"},
+			newArr:    []string{},
+			wantEdits: 2,
+		},
+		{
+			name:      "replace middle element",
+			oldArr:    []string{"a", "b", "c"},
+			newArr:    []string{"a", "x", "c"},
+			wantEdits: 2,
+		},
+		{
+			name:      "both empty",
+			oldArr:    []string{},
+			newArr:    []string{},
+			wantEdits: 0,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			script := arrays.ArrayDiff(tt.oldArr, tt.newArr)
+
+			edits, oldPos, newPos := 0, 0, 0
+			for _, e := range script {
+				switch e.Op {
+				case arrays.EditKeep:
+					if e.OldIndex != oldPos || e.NewIndex != newPos || tt.oldArr[oldPos] != tt.newArr[newPos] {
+						t.Errorf("unexpected keep %+v at %d/%d", e, oldPos, newPos)
+					}
+					oldPos++
+					newPos++
+				case arrays.EditDelete:
+					if e.OldIndex != oldPos || e.Value != tt.oldArr[oldPos] {
+						t.Errorf("unexpected delete %+v at %d", e, oldPos)
+					}
+					oldPos++
+					edits++
+				case arrays.EditInsert:
+					if e.NewIndex != newPos || e.Value != tt.newArr[newPos] {
+						t.Errorf("unexpected insert %+v at %d", e, newPos)
+					}
+					newPos++
+					edits++
+				}
+			}
+
+			if edits != tt.wantEdits {
+				t.Errorf("got %d edits, want %d", edits, tt.wantEdits)
+			}
+
+			got, err := arrays.ArrayPatch(tt.oldArr, script)
+			if err != nil {
+				t.Fatalf("ArrayPatch() error = %v", err)
+			}
+
+			if strings.Join(got, "") != strings.Join(tt.newArr, "") {
+				t.Errorf("got %v, want %v", got, tt.newArr)
+			}
+		})
+	}
+}
+
+func TestArrayDiffBy(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		id      int
+		payload []byte
+	}
+
+	oldArr := []item{{id: 1}, {id: 2}, {id: 3}}
+	newArr := []item{{id: 1}, {id: 3}, {id: 4}}
+
+	script := arrays.ArrayDiffBy(oldArr, newArr, func(a, b item) bool {
+		return a.id == b.id
+	})
+
+	want := []struct {
+		op arrays.EditOp
+		id int
+	}{
+		{op: arrays.EditKeep, id: 1},
+		{op: arrays.EditDelete, id: 2},
+		{op: arrays.EditKeep, id: 3},
+		{op: arrays.EditInsert, id: 4},
+	}
+
+	if len(script) != len(want) {
+		t.Fatalf("got %v, want %v", script, want)
+	}
+
+	for i, w := range want {
+		if script[i].Op != w.op || script[i].Value.id != w.id {
+			t.Errorf("got %s %d, want %s %d", script[i].Op, script[i].Value.id, w.op, w.id)
+		}
+	}
+}
+
+// lcsLength returns the length of the longest common subsequence with the quadratic dynamic programming.
+func lcsLength(a, b []int) int {
+	prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+
+	for i := range a {
+		for j := range b {
+			switch {
+			case a[i] == b[j]:
+				cur[j+1] = prev[j] + 1
+			case prev[j+1] > cur[j]:
+				cur[j+1] = prev[j+1]
+			default:
+				cur[j+1] = cur[j]
+			}
+		}
+
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
+
+func TestArrayDiffMinimal(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(5))
+
+	for i := 0; i < 500; i++ {
+		oldArr := make([]int, rnd.Intn(40))
+		for j := range oldArr {
+			oldArr[j] = rnd.Intn(4)
+		}
+
+		newArr := make([]int, rnd.Intn(40))
+		for j := range newArr {
+			newArr[j] = rnd.Intn(4)
+		}
+
+		script := arrays.ArrayDiff(oldArr, newArr)
+
+		got, err := arrays.ArrayPatch(oldArr, script)
+		if err != nil {
+			t.Fatalf("ArrayPatch(%v, %v) error = %v", oldArr, newArr, err)
+		}
+
+		if !reflect.DeepEqual(got, newArr) {
+			t.Fatalf("ArrayPatch(%v) = %v, want %v", oldArr, got, newArr)
+		}
+
+		keeps := 0
+		for _, e := range script {
+			if e.Op == arrays.EditKeep {
+				keeps++
+			}
+		}
+
+		if want := lcsLength(oldArr, newArr); keeps != want {
+			t.Fatalf("diff of %v and %v keeps %d elements, want %d", oldArr, newArr, keeps, want)
+		}
+	}
+}
+
+// TestArrayDiffMemory isn't parallel so other tests don't affect the allocation stats.
+func TestArrayDiffMemory(t *testing.T) {
+	const size, changes = 100_000, 300
+
+	rnd := rand.New(rand.NewSource(9))
+	oldArr := make([]int, size)
+
+	for i := range oldArr {
+		oldArr[i] = i
+	}
+
+	newArr := append([]int(nil), oldArr...)
+	for i := 0; i < changes; i++ {
+		newArr[rnd.Intn(size)] = -i - 1
+	}
+
+	var before, after runtime.MemStats
+
+	runtime.ReadMemStats(&before)
+
+	script := arrays.ArrayDiff(oldArr, newArr)
+
+	runtime.ReadMemStats(&after)
+
+	// The script alone takes about 6 MB, a trace of the whole search would take gigabytes.
+	const limit = 16 << 20
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > limit {
+		t.Errorf("ArrayDiff() allocated %d bytes, want at most %d", allocated, limit)
+	}
+
+	got, err := arrays.ArrayPatch(oldArr, script)
+	if err != nil {
+		t.Fatalf("ArrayPatch() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, newArr) {
+		t.Error("ArrayPatch() did not reproduce the new array")
+	}
+}
+
+func TestArrayPatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		arr       []int
+		script    []arrays.Edit[int]
+		want      []int
+		wantError bool
+	}{
+		{
+			name: "apply script",
+			arr:  []int{1, 2, 3},
+			script: []arrays.Edit[int]{
+				{Op: arrays.EditKeep, OldIndex: 0},
+				{Op: arrays.EditDelete, OldIndex: 1},
+				{Op: arrays.EditInsert, Value: 5},
+				{Op: arrays.EditKeep, OldIndex: 2},
+			},
+			want: []int{1, 5, 3},
+		},
+		{
+			name: "script skips element",
+			arr:  []int{1, 2, 3},
+			script: []arrays.Edit[int]{
+				{Op: arrays.EditKeep, OldIndex: 0},
+				{Op: arrays.EditKeep, OldIndex: 2},
+			},
+			wantError: true,
+		},
+		{
+			name: "script does not cover array",
+			arr:  []int{1, 2, 3},
+			script: []arrays.Edit[int]{
+				{Op: arrays.EditKeep, OldIndex: 0},
+			},
+			wantError: true,
+		},
+		{
+			name: "script is longer than array",
+			arr:  []int{1},
+			script: []arrays.Edit[int]{
+				{Op: arrays.EditKeep, OldIndex: 0},
+				{Op: arrays.EditDelete, OldIndex: 1},
+			},
+			wantError: true,
+		},
+		{
+			name:   "empty array and script",
+			arr:    []int{},
+			script: []arrays.Edit[int]{},
+			want:   []int{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := arrays.ArrayPatch(tt.arr, tt.script)
+
+			if (err != nil) != tt.wantError {
+				t.Fatalf("ArrayPatch() error = %v, wantError %v", err, tt.wantError)
+			}
+
+			if tt.wantError {
+				if !errors.Is(err, arrays.ErrPatchMismatch) {
+					t.Errorf("got error %v, want %v", err, arrays.ErrPatchMismatch)
+				}
+
+				return
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+
+			for i, v := range tt.want {
+				if got[i] != v {
+					t.Errorf("got %v, want %v", got[i], v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package arrays
+
+import (
+	"expvar"
+	"sync/atomic"
+	"time"
+)
+
+// ExpvarHooks is a Hooks implementation that publishes counters of operations via expvar.
+// Counters are cumulative over all operations the hooks are passed to:
+//   - operations: number of started operations
+//   - total: number of elements in started operations
+//   - started, finished: number of started and finished elements
+//   - element_errors: number of elements that failed
+//   - failed_operations: number of operations that failed
+//   - latency_ns: sum of per-element latencies in nanoseconds
+//   - throughput: finished elements per second since the first operation started
+type ExpvarHooks struct {
+	vars       *expvar.Map
+	operations *expvar.Int
+	total      *expvar.Int
+	started    *expvar.Int
+	finished   *expvar.Int
+	elemErrors *expvar.Int
+	failedOps  *expvar.Int
+	latency    *expvar.Int
+	clock      Clock
+	firstStart int64
+}
+
+// ExpvarOption configures ExpvarHooks.
+type ExpvarOption func(*ExpvarHooks)
+
+// WithExpvarClock sets the clock used to compute the throughput, SystemClock by default.
+func WithExpvarClock(clock Clock) ExpvarOption {
+	return func(h *ExpvarHooks) {
+		h.clock = clock
+	}
+}
+
+// NewExpvarHooks creates hooks publishing counters as an expvar map with the provided name.
+// If an expvar map with this name is already published, its counters are reset and reused.
+// Like expvar.Publish, it panics if the name is registered for a variable of another type.
+func NewExpvarHooks(name string, opts ...ExpvarOption) *ExpvarHooks {
+	vars, ok := expvar.Get(name).(*expvar.Map)
+	if !ok {
+		vars = expvar.NewMap(name)
+	}
+
+	h := &ExpvarHooks{
+		vars:       vars,
+		operations: new(expvar.Int),
+		total:      new(expvar.Int),
+		started:    new(expvar.Int),
+		finished:   new(expvar.Int),
+		elemErrors: new(expvar.Int),
+		failedOps:  new(expvar.Int),
+		latency:    new(expvar.Int),
+		clock:      SystemClock,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	h.vars.Set("operations", h.operations)
+	h.vars.Set("total", h.total)
+	h.vars.Set("started", h.started)
+	h.vars.Set("finished", h.finished)
+	h.vars.Set("element_errors", h.elemErrors)
+	h.vars.Set("failed_operations", h.failedOps)
+	h.vars.Set("latency_ns", h.latency)
+	h.vars.Set("throughput", expvar.Func(func() any {
+		return h.Throughput()
+	}))
+
+	return h
+}
+
+// Vars returns the published expvar map.
+func (h *ExpvarHooks) Vars() *expvar.Map {
+	return h.vars
+}
+
+// Throughput returns the number of finished elements per second since the first operation started.
+func (h *ExpvarHooks) Throughput() float64 {
+	first := atomic.LoadInt64(&h.firstStart)
+	if first == 0 {
+		return 0
+	}
+
+	elapsed := h.clock.Now().Sub(time.Unix(0, first)).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+
+	return float64(h.finished.Value()) / elapsed
+}
+
+// OnStart implements Hooks.
+func (h *ExpvarHooks) OnStart(total int) {
+	atomic.CompareAndSwapInt64(&h.firstStart, 0, h.clock.Now().UnixNano())
+	h.operations.Add(1)
+	h.total.Add(int64(total))
+}
+
+// OnElementStart implements Hooks.
+func (h *ExpvarHooks) OnElementStart(int) {
+	h.started.Add(1)
+}
+
+// OnElementDone implements Hooks.
+func (h *ExpvarHooks) OnElementDone(_ int, latency time.Duration, err error) {
+	h.finished.Add(1)
+	h.latency.Add(int64(latency))
+
+	if err != nil {
+		h.elemErrors.Add(1)
+	}
+}
+
+// OnFinish implements Hooks.
+func (h *ExpvarHooks) OnFinish(_ time.Duration, err error) {
+	if err != nil {
+		h.failedOps.Add(1)
+	}
+}
@@ -0,0 +1,62 @@
+package arrays_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+func TestExpvarHooks(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock()
+	hooks := arrays.NewExpvarHooks("arrays_test_expvar_hooks", arrays.WithExpvarClock(clock))
+
+	if got := hooks.Throughput(); got != 0 {
+		t.Errorf("got throughput %v before start, want 0", got)
+	}
+
+	_, err := arrays.ArrayProcessErr([]int{1, 2, 3}, func(v int) (int, error) {
+		return v, nil
+	}, arrays.WithHooks(hooks))
+	if err != nil {
+		t.Fatalf("ArrayProcessErr() error = %v", err)
+	}
+
+	// A single failed element fails the whole operation.
+	_, err = arrays.ArrayProcessErr([]int{1, 2}, func(v int) (int, error) {
+		if v == 2 {
+			return 0, errTransient
+		}
+
+		return v, nil
+	}, arrays.WithHooks(hooks))
+	if err == nil {
+		t.Fatal("ArrayProcessErr() error = nil, want error")
+	}
+
+	clock.Advance(2 * time.Second)
+
+	var got map[string]float64
+	if err := json.Unmarshal([]byte(hooks.Vars().String()), &got); err != nil {
+		t.Fatalf("invalid expvar json: %v", err)
+	}
+
+	want := map[string]float64{
+		"operations":        2,
+		"total":             5,
+		"started":           5,
+		"finished":          5,
+		"element_errors":    1,
+		"failed_operations": 1,
+		"throughput":        2.5,
+	}
+
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("got %s = %v, want %v", k, got[k], v)
+		}
+	}
+}
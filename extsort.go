@@ -0,0 +1,525 @@
+package arrays
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	defaultMemoryBudget = 64 << 20
+	defaultMaxOpenRuns  = 64
+)
+
+// Codec converts records to bytes and back for ExternalSort.
+type Codec[T any] struct {
+	Encode func(value T) ([]byte, error)
+	Decode func(data []byte) (T, error)
+}
+
+// JSONCodec returns a Codec using encoding/json.
+func JSONCodec[T any]() Codec[T] {
+	return Codec[T]{
+		Encode: func(value T) ([]byte, error) {
+			return json.Marshal(value)
+		},
+		Decode: func(data []byte) (T, error) {
+			var r T
+			err := json.Unmarshal(data, &r)
+
+			return r, err
+		},
+	}
+}
+
+type externalSortConfig struct {
+	memoryBudget int
+	tempDir      string
+	maxOpenRuns  int
+}
+
+// ExternalSortOption configures ExternalSort.
+type ExternalSortOption func(*externalSortConfig)
+
+// WithMemoryBudget sets the approximate number of bytes of encoded records sorted in memory at once, 64 MiB by default.
+func WithMemoryBudget(bytes int) ExternalSortOption {
+	return func(c *externalSortConfig) {
+		if bytes > 0 {
+			c.memoryBudget = bytes
+		}
+	}
+}
+
+// WithTempDir sets the directory for temporary files, os.TempDir by default.
+func WithTempDir(dir string) ExternalSortOption {
+	return func(c *externalSortConfig) {
+		c.tempDir = dir
+	}
+}
+
+// WithMaxOpenRuns sets the maximum number of temporary files merged at once, 64 by default.
+// When there are more runs, they are merged in several passes.
+func WithMaxOpenRuns(n int) ExternalSortOption {
+	return func(c *externalSortConfig) {
+		if n > 1 {
+			c.maxOpenRuns = n
+		}
+	}
+}
+
+// SortedIterator iterates over records sorted by ExternalSort.
+// Close must be called to remove temporary files unless the iterator has been read to the end.
+type SortedIterator[T any] struct {
+	next   func() (T, bool, error)
+	value  T
+	err    error
+	done   bool
+	closer func() error
+}
+
+// Next advances the iterator to the next record, returns false when there are no more records or on error.
+func (it *SortedIterator[T]) Next() bool {
+	if it.done {
+		return false
+	}
+
+	v, ok, err := it.next()
+	if err != nil || !ok {
+		it.err = err
+		it.value = *new(T)
+
+		if closeErr := it.Close(); it.err == nil {
+			it.err = closeErr
+		}
+
+		return false
+	}
+
+	it.value = v
+
+	return true
+}
+
+// Value returns the current record.
+func (it *SortedIterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *SortedIterator[T]) Err() error {
+	return it.err
+}
+
+// Close stops the iteration and removes temporary files. It's safe to call it several times.
+func (it *SortedIterator[T]) Close() error {
+	if it.done {
+		return nil
+	}
+
+	it.done = true
+
+	if it.closer != nil {
+		return it.closer()
+	}
+
+	return nil
+}
+
+// ExternalSort sorts records that may not fit in memory.
+// Records are read from next until it returns io.EOF, sorted in memory in runs bounded by the memory budget,
+// spilled to temporary files with the codec and merged back. The sort is stable.
+// If all records fit in the budget, no files are created.
+func ExternalSort[T any](
+	next func() (T, error),
+	less func(a, b T) bool,
+	codec Codec[T],
+	opts ...ExternalSortOption,
+) (*SortedIterator[T], error) {
+	c := externalSortConfig{memoryBudget: defaultMemoryBudget, maxOpenRuns: defaultMaxOpenRuns}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	s := &externalSorter[T]{config: c, less: less, codec: codec}
+
+	it, err := s.sort(next)
+	if err != nil {
+		_ = s.cleanup()
+
+		return nil, err
+	}
+
+	return it, nil
+}
+
+type externalSorter[T any] struct {
+	config externalSortConfig
+	less   func(a, b T) bool
+	codec  Codec[T]
+	dir    string
+	runs   []string
+	seq    int
+}
+
+func (s *externalSorter[T]) cleanup() error {
+	if s.dir == "" {
+		return nil
+	}
+
+	if err := os.RemoveAll(s.dir); err != nil {
+		return fmt.Errorf("remove temp dir: %w", err)
+	}
+
+	return nil
+}
+
+// encoded is a record along with its encoded form, so every record is encoded only once.
+type encoded[T any] struct {
+	value T
+	data  []byte
+}
+
+func (s *externalSorter[T]) sort(next func() (T, error)) (*SortedIterator[T], error) {
+	buf := make([]encoded[T], 0)
+	size := 0
+
+	for {
+		v, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("next: %w", err)
+		}
+
+		data, err := s.codec.Encode(v)
+		if err != nil {
+			return nil, fmt.Errorf("encode: %w", err)
+		}
+
+		buf = append(buf, encoded[T]{value: v, data: data})
+		size += len(data)
+
+		if size >= s.config.memoryBudget {
+			if err := s.spill(buf); err != nil {
+				return nil, err
+			}
+
+			buf, size = buf[:0], 0
+		}
+	}
+
+	s.sortBuffer(buf)
+
+	if len(s.runs) == 0 {
+		i := 0
+
+		return &SortedIterator[T]{next: func() (T, bool, error) {
+			if i >= len(buf) {
+				return *new(T), false, nil
+			}
+
+			i++
+
+			return buf[i-1].value, true, nil
+		}}, nil
+	}
+
+	if len(buf) > 0 {
+		if err := s.writeRun(buf); err != nil {
+			return nil, err
+		}
+	}
+
+	for len(s.runs) > s.config.maxOpenRuns {
+		if err := s.mergePass(); err != nil {
+			return nil, err
+		}
+	}
+
+	m, err := s.openMerger(s.runs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SortedIterator[T]{
+		next: func() (T, bool, error) {
+			r, ok, err := m.next()
+
+			return r.value, ok, err
+		},
+		closer: func() error {
+			closeErr := m.close()
+			if err := s.cleanup(); err != nil {
+				return err
+			}
+
+			return closeErr
+		},
+	}, nil
+}
+
+func (s *externalSorter[T]) sortBuffer(buf []encoded[T]) {
+	sort.SliceStable(buf, func(i, j int) bool {
+		return s.less(buf[i].value, buf[j].value)
+	})
+}
+
+func (s *externalSorter[T]) spill(buf []encoded[T]) error {
+	s.sortBuffer(buf)
+
+	return s.writeRun(buf)
+}
+
+func (s *externalSorter[T]) newRunFile() (*os.File, error) {
+	if s.dir == "" {
+		dir, err := os.MkdirTemp(s.config.tempDir, "arrays-sort-*")
+		if err != nil {
+			return nil, fmt.Errorf("create temp dir: %w", err)
+		}
+
+		s.dir = dir
+	}
+
+	s.seq++
+
+	f, err := os.Create(filepath.Join(s.dir, fmt.Sprintf("run-%06d", s.seq)))
+	if err != nil {
+		return nil, fmt.Errorf("create run: %w", err)
+	}
+
+	return f, nil
+}
+
+// writeRunFrom writes sorted records produced by next to a new run file, using their encoded form as is.
+func (s *externalSorter[T]) writeRunFrom(next func() (encoded[T], bool, error)) (string, error) {
+	f, err := s.newRunFile()
+	if err != nil {
+		return "", err
+	}
+
+	w := bufio.NewWriter(f)
+	header := make([]byte, binary.MaxVarintLen64)
+
+	for {
+		r, ok, err := next()
+		if err != nil {
+			f.Close()
+
+			return "", err
+		}
+
+		if !ok {
+			break
+		}
+
+		n := binary.PutUvarint(header, uint64(len(r.data)))
+		if _, err := w.Write(header[:n]); err != nil {
+			f.Close()
+
+			return "", fmt.Errorf("write run: %w", err)
+		}
+
+		if _, err := w.Write(r.data); err != nil {
+			f.Close()
+
+			return "", fmt.Errorf("write run: %w", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+
+		return "", fmt.Errorf("write run: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("close run: %w", err)
+	}
+
+	return f.Name(), nil
+}
+
+func (s *externalSorter[T]) writeRun(buf []encoded[T]) error {
+	i := 0
+
+	name, err := s.writeRunFrom(func() (encoded[T], bool, error) {
+		if i >= len(buf) {
+			return encoded[T]{}, false, nil
+		}
+
+		i++
+
+		return buf[i-1], true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.runs = append(s.runs, name)
+
+	return nil
+}
+
+// mergePass merges groups of runs into larger runs, keeping their order for stability.
+func (s *externalSorter[T]) mergePass() error {
+	merged := make([]string, 0, len(s.runs)/s.config.maxOpenRuns+1)
+
+	for start := 0; start < len(s.runs); start += s.config.maxOpenRuns {
+		end := start + s.config.maxOpenRuns
+		if end > len(s.runs) {
+			end = len(s.runs)
+		}
+
+		m, err := s.openMerger(s.runs[start:end])
+		if err != nil {
+			return err
+		}
+
+		name, err := s.writeRunFrom(m.next)
+		closeErr := m.close()
+
+		if err != nil {
+			return err
+		}
+
+		if closeErr != nil {
+			return closeErr
+		}
+
+		for _, run := range s.runs[start:end] {
+			if err := os.Remove(run); err != nil {
+				return fmt.Errorf("remove run: %w", err)
+			}
+		}
+
+		merged = append(merged, name)
+	}
+
+	s.runs = merged
+
+	return nil
+}
+
+type runReader[T any] struct {
+	f     *os.File
+	r     *bufio.Reader
+	codec Codec[T]
+}
+
+// read returns the next record of the run, keeping its encoded form for merge passes.
+func (r *runReader[T]) read() (encoded[T], bool, error) {
+	size, err := binary.ReadUvarint(r.r)
+	if errors.Is(err, io.EOF) {
+		return encoded[T]{}, false, nil
+	}
+
+	if err != nil {
+		return encoded[T]{}, false, fmt.Errorf("read run: %w", err)
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r.r, data); err != nil {
+		return encoded[T]{}, false, fmt.Errorf("read run: %w", err)
+	}
+
+	v, err := r.codec.Decode(data)
+	if err != nil {
+		return encoded[T]{}, false, fmt.Errorf("decode: %w", err)
+	}
+
+	return encoded[T]{value: v, data: data}, true, nil
+}
+
+type runHead[T any] struct {
+	record encoded[T]
+	run    int
+}
+
+type runMerger[T any] struct {
+	readers []*runReader[T]
+	heap    *Heap[runHead[T]]
+}
+
+func (s *externalSorter[T]) openMerger(runs []string) (*runMerger[T], error) {
+	m := &runMerger[T]{
+		heap: NewHeap(func(a, b runHead[T]) bool {
+			if s.less(a.record.value, b.record.value) {
+				return true
+			}
+
+			if s.less(b.record.value, a.record.value) {
+				return false
+			}
+
+			return a.run < b.run
+		}),
+	}
+
+	for i, name := range runs {
+		f, err := os.Open(name)
+		if err != nil {
+			m.close()
+
+			return nil, fmt.Errorf("open run: %w", err)
+		}
+
+		r := &runReader[T]{f: f, r: bufio.NewReader(f), codec: s.codec}
+		m.readers = append(m.readers, r)
+
+		v, ok, err := r.read()
+		if err != nil {
+			m.close()
+
+			return nil, err
+		}
+
+		if ok {
+			m.heap.Push(runHead[T]{record: v, run: i})
+		}
+	}
+
+	return m, nil
+}
+
+func (m *runMerger[T]) next() (encoded[T], bool, error) {
+	head, ok := m.heap.Peek()
+	if !ok {
+		return encoded[T]{}, false, nil
+	}
+
+	v, ok, err := m.readers[head.run].read()
+	if err != nil {
+		return encoded[T]{}, false, err
+	}
+
+	if ok {
+		m.heap.Update(0, runHead[T]{record: v, run: head.run})
+	} else {
+		m.heap.Pop()
+	}
+
+	return head.record, true, nil
+}
+
+func (m *runMerger[T]) close() error {
+	var firstErr error
+
+	for _, r := range m.readers {
+		if err := r.f.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("close run: %w", err)
+		}
+	}
+
+	m.readers = nil
+
+	return firstErr
+}
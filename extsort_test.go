@@ -0,0 +1,231 @@
+package arrays_test
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+type record struct {
+	Key int `json:"key"`
+	Seq int `json:"seq"`
+}
+
+func recordSource(records []record) func() (record, error) {
+	i := 0
+
+	return func() (record, error) {
+		if i >= len(records) {
+			return record{}, io.EOF
+		}
+
+		i++
+
+		return records[i-1], nil
+	}
+}
+
+func randomRecords(n int) []record {
+	rnd := rand.New(rand.NewSource(3))
+	r := make([]record, n)
+
+	for i := range r {
+		r[i] = record{Key: rnd.Intn(n / 10), Seq: i}
+	}
+
+	return r
+}
+
+func recordLess(a, b record) bool {
+	return a.Key < b.Key
+}
+
+func assertTempDirEmpty(t *testing.T, dir string) {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+
+	if len(entries) != 0 {
+		t.Errorf("temp dir is not empty: %v", entries)
+	}
+}
+
+func TestExternalSort(t *testing.T) {
+	t.Parallel()
+
+	records := randomRecords(5000)
+
+	tests := []struct {
+		name string
+		opts []arrays.ExternalSortOption
+	}{
+		{
+			name: "fits in memory",
+		},
+		{
+			name: "spilled runs",
+			opts: []arrays.ExternalSortOption{arrays.WithMemoryBudget(4096)},
+		},
+		{
+			name: "several merge passes",
+			opts: []arrays.ExternalSortOption{arrays.WithMemoryBudget(1024), arrays.WithMaxOpenRuns(3)},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			opts := append([]arrays.ExternalSortOption{arrays.WithTempDir(dir)}, tt.opts...)
+
+			it, err := arrays.ExternalSort(recordSource(records), recordLess, arrays.JSONCodec[record](), opts...)
+			if err != nil {
+				t.Fatalf("ExternalSort() error = %v", err)
+			}
+
+			want := append([]record(nil), records...)
+			sort.SliceStable(want, func(i, j int) bool { return recordLess(want[i], want[j]) })
+
+			got := make([]record, 0, len(records))
+			for it.Next() {
+				got = append(got, it.Value())
+			}
+
+			if err := it.Err(); err != nil {
+				t.Fatalf("Err() = %v", err)
+			}
+
+			if len(got) != len(want) {
+				t.Fatalf("got %d records, want %d", len(got), len(want))
+			}
+
+			for i := range want {
+				if got[i] != want[i] {
+					t.Fatalf("record %d: got %v, want %v", i, got[i], want[i])
+				}
+			}
+
+			assertTempDirEmpty(t, dir)
+		})
+	}
+}
+
+func TestExternalSortEncodesOnce(t *testing.T) {
+	t.Parallel()
+
+	records := randomRecords(2000)
+	encodes := 0
+
+	codec := arrays.JSONCodec[record]()
+	encode := codec.Encode
+	codec.Encode = func(r record) ([]byte, error) {
+		encodes++
+
+		return encode(r)
+	}
+
+	it, err := arrays.ExternalSort(recordSource(records), recordLess, codec,
+		arrays.WithTempDir(t.TempDir()), arrays.WithMemoryBudget(1024), arrays.WithMaxOpenRuns(2))
+	if err != nil {
+		t.Fatalf("ExternalSort() error = %v", err)
+	}
+
+	n := 0
+	for it.Next() {
+		n++
+	}
+
+	if err := it.Err(); err != nil || n != len(records) {
+		t.Fatalf("got %d records, error = %v, want %d", n, err, len(records))
+	}
+
+	if encodes != len(records) {
+		t.Errorf("Encode() called %d times, want %d", encodes, len(records))
+	}
+}
+
+func TestExternalSortClose(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	it, err := arrays.ExternalSort(recordSource(randomRecords(1000)), recordLess, arrays.JSONCodec[record](),
+		arrays.WithTempDir(dir), arrays.WithMemoryBudget(512))
+	if err != nil {
+		t.Fatalf("ExternalSort() error = %v", err)
+	}
+
+	if !it.Next() {
+		t.Fatalf("Next() = false, error = %v", it.Err())
+	}
+
+	if err := it.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if err := it.Close(); err != nil {
+		t.Fatalf("second Close() error = %v", err)
+	}
+
+	if it.Next() {
+		t.Error("Next() = true after Close()")
+	}
+
+	assertTempDirEmpty(t, dir)
+}
+
+func TestExternalSortErrors(t *testing.T) {
+	t.Parallel()
+
+	errSource := errors.New("source failed")
+	errDecode := errors.New("decode failed")
+
+	t.Run("source error", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		calls := 0
+
+		_, err := arrays.ExternalSort(func() (record, error) {
+			calls++
+			if calls > 100 {
+				return record{}, errSource
+			}
+			return record{Key: calls}, nil
+		}, recordLess, arrays.JSONCodec[record](), arrays.WithTempDir(dir), arrays.WithMemoryBudget(128))
+
+		if !errors.Is(err, errSource) {
+			t.Errorf("got error %v, want %v", err, errSource)
+		}
+
+		assertTempDirEmpty(t, dir)
+	})
+
+	t.Run("decode error", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		codec := arrays.JSONCodec[record]()
+		codec.Decode = func([]byte) (record, error) {
+			return record{}, errDecode
+		}
+
+		_, err := arrays.ExternalSort(recordSource(randomRecords(100)), recordLess, codec,
+			arrays.WithTempDir(dir), arrays.WithMemoryBudget(128))
+
+		if !errors.Is(err, errDecode) {
+			t.Errorf("got error %v, want %v", err, errDecode)
+		}
+
+		assertTempDirEmpty(t, dir)
+	})
+}
@@ -0,0 +1,339 @@
+package arrays
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// ErrHeapClosed is returned by SyncHeap.PopWait when the heap is closed and empty.
+var ErrHeapClosed = errors.New("heap is closed")
+
+// Heap is a binary heap ordered by a less function, the least element is on top.
+// Use a "greater" function to get a max-heap. It's not safe for concurrent use, see SyncHeap.
+type Heap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+// NewHeap creates an empty heap ordered by the less function.
+func NewHeap[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{less: less}
+}
+
+// NewHeapFrom creates a heap with a copy of the provided array in O(n).
+func NewHeapFrom[T any](arr []T, less func(a, b T) bool) *Heap[T] {
+	h := &Heap[T]{items: make([]T, len(arr)), less: less}
+	copy(h.items, arr)
+
+	for i := len(h.items)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+
+	return h
+}
+
+// Len returns the number of elements in the heap.
+func (h *Heap[T]) Len() int {
+	return len(h.items)
+}
+
+// Push adds the value to the heap in O(log n).
+func (h *Heap[T]) Push(value T) {
+	h.items = append(h.items, value)
+	h.up(len(h.items) - 1)
+}
+
+// Peek returns the least element without removing it, false if the heap is empty.
+func (h *Heap[T]) Peek() (T, bool) {
+	if len(h.items) == 0 {
+		return *new(T), false
+	}
+
+	return h.items[0], true
+}
+
+// Pop removes and returns the least element in O(log n), false if the heap is empty.
+func (h *Heap[T]) Pop() (T, bool) {
+	return h.Remove(0)
+}
+
+// Remove removes and returns the element at the index in O(log n), false if the index is out of range.
+// Indexes are positions in the internal array, see IndexFunc.
+func (h *Heap[T]) Remove(i int) (T, bool) {
+	if i < 0 || i >= len(h.items) {
+		return *new(T), false
+	}
+
+	last := len(h.items) - 1
+	r := h.items[i]
+
+	h.swap(i, last)
+	h.items[last] = *new(T)
+	h.items = h.items[:last]
+
+	if i < last {
+		h.Fix(i)
+	}
+
+	return r, true
+}
+
+// Fix restores the heap order after the element at the index has been changed in O(log n).
+func (h *Heap[T]) Fix(i int) {
+	if i < 0 || i >= len(h.items) {
+		return
+	}
+
+	if !h.down(i) {
+		h.up(i)
+	}
+}
+
+// Update replaces the element at the index and restores the heap order, false if the index is out of range.
+func (h *Heap[T]) Update(i int, value T) bool {
+	if i < 0 || i >= len(h.items) {
+		return false
+	}
+
+	h.items[i] = value
+	h.Fix(i)
+
+	return true
+}
+
+// IndexFunc returns the index of the first element satisfying the provided testing function, -1 if none does.
+func (h *Heap[T]) IndexFunc(callback func(value T) bool) int {
+	i, _ := ArrayFindIndex(h.items, func(_ int, v T) bool {
+		return callback(v)
+	})
+
+	return i
+}
+
+// ToSlice returns a copy of the elements in heap order, which is not sorted.
+func (h *Heap[T]) ToSlice() []T {
+	r := make([]T, len(h.items))
+	copy(r, h.items)
+
+	return r
+}
+
+// Sorted returns a copy of the elements sorted from the least to the greatest.
+func (h *Heap[T]) Sorted() []T {
+	r := h.ToSlice()
+
+	sort.Slice(r, func(i, j int) bool {
+		return h.less(r[i], r[j])
+	})
+
+	return r
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.items[i], h.items[parent]) {
+			return
+		}
+
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the element at the index down and reports whether it has moved.
+func (h *Heap[T]) down(i int) bool {
+	start := i
+	n := len(h.items)
+
+	for {
+		least := 2*i + 1
+		if least >= n {
+			break
+		}
+
+		if right := least + 1; right < n && h.less(h.items[right], h.items[least]) {
+			least = right
+		}
+
+		if !h.less(h.items[least], h.items[i]) {
+			break
+		}
+
+		h.swap(i, least)
+		i = least
+	}
+
+	return i > start
+}
+
+// BoundedHeap keeps only the k greatest elements pushed to it according to the less function.
+// The least of the kept elements is on top, so it's evicted first.
+// It's not safe for concurrent use.
+type BoundedHeap[T any] struct {
+	heap *Heap[T]
+	k    int
+}
+
+// NewBoundedHeap creates an empty heap keeping at most k elements.
+func NewBoundedHeap[T any](k int, less func(a, b T) bool) *BoundedHeap[T] {
+	if k < 0 {
+		k = 0
+	}
+
+	return &BoundedHeap[T]{heap: NewHeap(less), k: k}
+}
+
+// Push adds the value if the heap isn't full or the value is greater than the least kept element,
+// which is evicted then. Reports whether the value was kept.
+func (h *BoundedHeap[T]) Push(value T) bool {
+	if h.heap.Len() < h.k {
+		h.heap.Push(value)
+
+		return true
+	}
+
+	if h.k == 0 || !h.heap.less(h.heap.items[0], value) {
+		return false
+	}
+
+	h.heap.Update(0, value)
+
+	return true
+}
+
+// Len returns the number of kept elements.
+func (h *BoundedHeap[T]) Len() int {
+	return h.heap.Len()
+}
+
+// Peek returns the least kept element without removing it, false if the heap is empty.
+func (h *BoundedHeap[T]) Peek() (T, bool) {
+	return h.heap.Peek()
+}
+
+// Pop removes and returns the least kept element, false if the heap is empty.
+func (h *BoundedHeap[T]) Pop() (T, bool) {
+	return h.heap.Pop()
+}
+
+// Sorted returns a copy of the kept elements sorted from the greatest to the least.
+func (h *BoundedHeap[T]) Sorted() []T {
+	return ArrayReverse(h.heap.Sorted())
+}
+
+// SyncHeap is a Heap safe for concurrent use, suitable as a priority job queue.
+type SyncHeap[T any] struct {
+	mu     sync.Mutex
+	heap   *Heap[T]
+	ready  chan struct{}
+	closed bool
+}
+
+// NewSyncHeap creates an empty concurrent heap ordered by the less function.
+func NewSyncHeap[T any](less func(a, b T) bool) *SyncHeap[T] {
+	return &SyncHeap[T]{
+		heap:  NewHeap(less),
+		ready: make(chan struct{}, 1),
+	}
+}
+
+// signal wakes up one waiting PopWait call, must be called with the lock held.
+func (h *SyncHeap[T]) signal() {
+	if h.closed {
+		return
+	}
+
+	select {
+	case h.ready <- struct{}{}:
+	default:
+	}
+}
+
+// Push adds the value to the heap. Returns ErrHeapClosed if the heap is closed.
+func (h *SyncHeap[T]) Push(value T) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return ErrHeapClosed
+	}
+
+	h.heap.Push(value)
+	h.signal()
+
+	return nil
+}
+
+// Pop removes and returns the least element without waiting, false if the heap is empty.
+func (h *SyncHeap[T]) Pop() (T, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.heap.Pop()
+}
+
+// PopWait removes and returns the least element, waiting for one to be pushed if the heap is empty.
+// Returns ErrHeapClosed if the heap is closed and empty, or the context error if it's done first.
+func (h *SyncHeap[T]) PopWait(ctx context.Context) (T, error) {
+	for {
+		h.mu.Lock()
+
+		if v, ok := h.heap.Pop(); ok {
+			if h.heap.Len() > 0 {
+				h.signal()
+			}
+
+			h.mu.Unlock()
+
+			return v, nil
+		}
+
+		closed := h.closed
+		h.mu.Unlock()
+
+		if closed {
+			return *new(T), ErrHeapClosed
+		}
+
+		select {
+		case <-ctx.Done():
+			return *new(T), ctx.Err()
+		case <-h.ready:
+		}
+	}
+}
+
+// Peek returns the least element without removing it, false if the heap is empty.
+func (h *SyncHeap[T]) Peek() (T, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.heap.Peek()
+}
+
+// Len returns the number of elements in the heap.
+func (h *SyncHeap[T]) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.heap.Len()
+}
+
+// Close stops accepting new elements. Remaining elements can still be popped,
+// after that PopWait returns ErrHeapClosed.
+func (h *SyncHeap[T]) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.closed {
+		h.closed = true
+		close(h.ready)
+	}
+}
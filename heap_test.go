@@ -0,0 +1,239 @@
+package arrays_test
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+func intLess(a, b int) bool {
+	return a < b
+}
+
+func drainHeap(h *arrays.Heap[int]) []int {
+	r := make([]int, 0, h.Len())
+
+	for {
+		v, ok := h.Pop()
+		if !ok {
+			return r
+		}
+
+		r = append(r, v)
+	}
+}
+
+func TestHeap(t *testing.T) {
+	t.Parallel()
+
+	arr := rand.New(rand.NewSource(1)).Perm(100)
+
+	tests := []struct {
+		name string
+		heap func() *arrays.Heap[int]
+	}{
+		{
+			name: "push one by one",
+			heap: func() *arrays.Heap[int] {
+				h := arrays.NewHeap(intLess)
+				for _, v := range arr {
+					h.Push(v)
+				}
+				return h
+			},
+		},
+		{
+			name: "build from slice",
+			heap: func() *arrays.Heap[int] {
+				return arrays.NewHeapFrom(arr, intLess)
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := tt.heap()
+
+			if v, ok := h.Peek(); !ok || v != 0 {
+				t.Errorf("Peek() = %v, %v, want 0, true", v, ok)
+			}
+
+			got := drainHeap(h)
+			if len(got) != len(arr) || !sort.IntsAreSorted(got) {
+				t.Errorf("got %v, want sorted %d elements", got, len(arr))
+			}
+
+			if _, ok := h.Pop(); ok {
+				t.Error("Pop() on empty heap returned a value")
+			}
+		})
+	}
+}
+
+func TestHeapRemoveAndFix(t *testing.T) {
+	t.Parallel()
+
+	h := arrays.NewHeapFrom([]int{5, 3, 8, 1, 9, 2}, intLess)
+
+	i := h.IndexFunc(func(v int) bool { return v == 8 })
+	if v, ok := h.Remove(i); !ok || v != 8 {
+		t.Errorf("Remove() = %v, %v, want 8, true", v, ok)
+	}
+
+	if _, ok := h.Remove(100); ok {
+		t.Error("Remove() out of range returned a value")
+	}
+
+	if !h.Update(h.IndexFunc(func(v int) bool { return v == 9 }), 0) {
+		t.Error("Update() = false")
+	}
+
+	if h.IndexFunc(func(v int) bool { return v == 42 }) != -1 {
+		t.Error("IndexFunc() found a missing value")
+	}
+
+	if got := h.Sorted(); len(got) != 5 || got[0] != 0 || got[4] != 5 {
+		t.Errorf("Sorted() = %v, want [0 1 2 3 5]", got)
+	}
+
+	want := []int{0, 1, 2, 3, 5}
+	got := drainHeap(h)
+
+	for i, v := range want {
+		if got[i] != v {
+			t.Errorf("got %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestBoundedHeap(t *testing.T) {
+	t.Parallel()
+
+	h := arrays.NewBoundedHeap(3, intLess)
+
+	for _, v := range []int{5, 1, 9, 3, 7, 2} {
+		h.Push(v)
+	}
+
+	if h.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", h.Len())
+	}
+
+	if v, _ := h.Peek(); v != 5 {
+		t.Errorf("Peek() = %v, want 5", v)
+	}
+
+	if got := h.Sorted(); len(got) != 3 || got[0] != 9 || got[1] != 7 || got[2] != 5 {
+		t.Errorf("Sorted() = %v, want [9 7 5]", got)
+	}
+
+	if h.Push(4) {
+		t.Error("Push(4) kept a value lower than all kept ones")
+	}
+
+	if v, ok := h.Pop(); !ok || v != 5 {
+		t.Errorf("Pop() = %v, %v, want 5, true", v, ok)
+	}
+
+	empty := arrays.NewBoundedHeap(0, intLess)
+	if empty.Push(1) || empty.Len() != 0 {
+		t.Error("zero-capacity heap kept a value")
+	}
+}
+
+func TestSyncHeap(t *testing.T) {
+	t.Parallel()
+
+	h := arrays.NewSyncHeap(intLess)
+
+	const workers, jobs = 4, 100
+
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		got []int
+	)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for {
+				v, err := h.PopWait(context.Background())
+				if errors.Is(err, arrays.ErrHeapClosed) {
+					return
+				}
+
+				mu.Lock()
+				got = append(got, v)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := 0; i < jobs; i++ {
+		if err := h.Push(i); err != nil {
+			t.Fatalf("Push() error = %v", err)
+		}
+	}
+
+	for h.Len() > 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	h.Close()
+	wg.Wait()
+
+	if len(got) != jobs {
+		t.Errorf("got %d jobs, want %d", len(got), jobs)
+	}
+
+	if err := h.Push(1); !errors.Is(err, arrays.ErrHeapClosed) {
+		t.Errorf("Push() after Close() error = %v, want %v", err, arrays.ErrHeapClosed)
+	}
+}
+
+func TestSyncHeapPopWait(t *testing.T) {
+	t.Parallel()
+
+	h := arrays.NewSyncHeap(intLess)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := h.PopWait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("PopWait() error = %v, want %v", err, context.Canceled)
+	}
+
+	_ = h.Push(3)
+	_ = h.Push(1)
+
+	if v, ok := h.Peek(); !ok || v != 1 {
+		t.Errorf("Peek() = %v, %v, want 1, true", v, ok)
+	}
+
+	h.Close()
+
+	if v, err := h.PopWait(context.Background()); err != nil || v != 1 {
+		t.Errorf("PopWait() = %v, %v, want 1, nil", v, err)
+	}
+
+	if v, ok := h.Pop(); !ok || v != 3 {
+		t.Errorf("Pop() = %v, %v, want 3, true", v, ok)
+	}
+
+	if _, err := h.PopWait(context.Background()); !errors.Is(err, arrays.ErrHeapClosed) {
+		t.Errorf("PopWait() error = %v, want %v", err, arrays.ErrHeapClosed)
+	}
+}
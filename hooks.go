@@ -0,0 +1,85 @@
+package arrays
+
+import "time"
+
+// Hooks observes the progress of batch and parallel operations.
+// Methods may be called concurrently by parallel operations.
+type Hooks interface {
+	// OnStart is called once before processing with the total number of elements.
+	OnStart(total int)
+	// OnElementStart is called before an element is processed.
+	OnElementStart(index int)
+	// OnElementDone is called after an element is processed, including all retries,
+	// with the time it took and the error returned for it.
+	OnElementDone(index int, latency time.Duration, err error)
+	// OnFinish is called once after processing with the total time and the error returned by the operation.
+	OnFinish(elapsed time.Duration, err error)
+}
+
+// NopHooks is a Hooks implementation that does nothing.
+// Embed it to implement only some of the methods.
+type NopHooks struct{}
+
+// OnStart implements Hooks.
+func (NopHooks) OnStart(int) {}
+
+// OnElementStart implements Hooks.
+func (NopHooks) OnElementStart(int) {}
+
+// OnElementDone implements Hooks.
+func (NopHooks) OnElementDone(int, time.Duration, error) {}
+
+// OnFinish implements Hooks.
+func (NopHooks) OnFinish(time.Duration, error) {}
+
+// WithHooks sets hooks notified about the progress of the operation.
+func WithHooks(hooks Hooks) ProcessOption {
+	return func(c *processConfig) {
+		c.hooks = hooks
+	}
+}
+
+func (c *processConfig) begin(total int) time.Time {
+	if c.hooks == nil {
+		return time.Time{}
+	}
+
+	c.hooks.OnStart(total)
+
+	return c.clock.Now()
+}
+
+func (c *processConfig) finish(start time.Time, err error) {
+	if c.hooks == nil {
+		return
+	}
+
+	c.hooks.OnFinish(c.clock.Now().Sub(start), err)
+}
+
+func (c *processConfig) elementStart(index int) time.Time {
+	if c.hooks == nil {
+		return time.Time{}
+	}
+
+	c.hooks.OnElementStart(index)
+
+	return c.clock.Now()
+}
+
+func (c *processConfig) elementDone(index int, start time.Time, err error) {
+	if c.hooks == nil {
+		return
+	}
+
+	c.hooks.OnElementDone(index, c.clock.Now().Sub(start), err)
+}
+
+// processElement calls processCall for the element at the index and reports it to the hooks.
+func processElement[I, T any](c *processConfig, index int, v I, callback func(value I) (T, error)) (T, error) {
+	start := c.elementStart(index)
+	res, err := processCall(c, v, callback)
+	c.elementDone(index, start, err)
+
+	return res, err
+}
@@ -0,0 +1,151 @@
+package arrays_test
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+type recordingHooks struct {
+	arrays.NopHooks
+
+	mu        sync.Mutex
+	total     int
+	started   []int
+	done      []int
+	failed    []int
+	latencies []time.Duration
+	finished  int
+	finishErr error
+}
+
+func (h *recordingHooks) OnStart(total int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.total = total
+}
+
+func (h *recordingHooks) OnElementStart(index int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.started = append(h.started, index)
+}
+
+func (h *recordingHooks) OnElementDone(index int, latency time.Duration, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.done = append(h.done, index)
+	h.latencies = append(h.latencies, latency)
+
+	if err != nil {
+		h.failed = append(h.failed, index)
+	}
+}
+
+func (h *recordingHooks) OnFinish(_ time.Duration, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.finished++
+	h.finishErr = err
+}
+
+func TestHooksArrayProcessErr(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock()
+	sleeper := &recordingSleeper{clock: clock}
+	hooks := &recordingHooks{}
+
+	_, err := arrays.ArrayProcessErr([]int{1, 2, 3}, flaky(1, errTransient),
+		arrays.WithHooks(hooks),
+		arrays.WithProcessClock(clock),
+		arrays.WithSleeper(sleeper.Sleep),
+		arrays.WithRetry(arrays.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Second}),
+	)
+	if err != nil {
+		t.Fatalf("ArrayProcessErr() error = %v", err)
+	}
+
+	if hooks.total != 3 || len(hooks.started) != 3 || len(hooks.done) != 3 || hooks.finished != 1 {
+		t.Errorf("got hooks %+v", hooks)
+	}
+
+	for _, latency := range hooks.latencies {
+		if latency != time.Second {
+			t.Errorf("got latency %v, want %v", latency, time.Second)
+		}
+	}
+}
+
+func TestHooksArrayMapErrFailure(t *testing.T) {
+	t.Parallel()
+
+	hooks := &recordingHooks{}
+
+	_, err := arrays.ArrayMapErr([]int{1, 2, 3}, func(i, v int) (int, error) {
+		if i == 1 {
+			return 0, errTransient
+		}
+		return v, nil
+	}, arrays.WithHooks(hooks))
+
+	if !errors.Is(err, errTransient) {
+		t.Fatalf("got error %v, want %v", err, errTransient)
+	}
+
+	if len(hooks.started) != 2 || len(hooks.failed) != 1 || hooks.failed[0] != 1 {
+		t.Errorf("got started %v, failed %v", hooks.started, hooks.failed)
+	}
+
+	if !errors.Is(hooks.finishErr, errTransient) {
+		t.Errorf("got finish error %v, want %v", hooks.finishErr, errTransient)
+	}
+}
+
+func TestHooksParallel(t *testing.T) {
+	t.Parallel()
+
+	arr := make([]int, 50)
+	for i := range arr {
+		arr[i] = i
+	}
+
+	processHooks := &recordingHooks{}
+
+	_, err := arrays.ArrayParallelProcessErr(arr, func(v int) (int, error) {
+		return v, nil
+	}, arrays.WithHooks(processHooks), arrays.WithParallelism(4))
+	if err != nil {
+		t.Fatalf("ArrayParallelProcessErr() error = %v", err)
+	}
+
+	reduceHooks := &recordingHooks{}
+	arrays.ArrayParallelReduce(arr, 0, func(acc, v int) int { return acc + v },
+		func(a, b int) int { return a + b }, arrays.WithHooks(reduceHooks), arrays.WithParallelism(4))
+
+	for _, hooks := range []*recordingHooks{processHooks, reduceHooks} {
+		if hooks.total != len(arr) || hooks.finished != 1 {
+			t.Errorf("got total %d, finished %d", hooks.total, hooks.finished)
+		}
+
+		sort.Ints(hooks.done)
+
+		if len(hooks.done) != len(arr) {
+			t.Fatalf("got %d done elements, want %d", len(hooks.done), len(arr))
+		}
+
+		for i, index := range hooks.done {
+			if index != i {
+				t.Errorf("got index %d, want %d", index, i)
+			}
+		}
+	}
+}
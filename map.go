@@ -1,5 +1,13 @@
 package arrays
 
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrKeyCollision is returned when several entries map to the same key and no resolver is provided.
+var ErrKeyCollision = errors.New("key collision")
+
 func MapWalk[I comparable, K, T any](arr map[I]K, callback func(key I, value K) T) []T {
 	r := make([]T, 0, len(arr))
 
@@ -47,3 +55,204 @@ func MapValues[I comparable, K any](arr map[I]K) []K {
 
 	return r
 }
+
+// MapChange holds the old and the new value of an entry changed between two maps.
+type MapChange[K any] struct {
+	Old K
+	New K
+}
+
+// MapDelta is a keyed difference between two maps produced by MapDiff.
+type MapDelta[I comparable, K any] struct {
+	Added   map[I]K
+	Removed map[I]K
+	Changed map[I]MapChange[K]
+}
+
+// Empty reports whether the delta contains no differences.
+func (d MapDelta[I, K]) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// MapDiff returns entries added to, removed from and changed in the new map compared to the old one.
+// For non-comparable values use MapDiffBy.
+func MapDiff[I, K comparable](oldMap, newMap map[I]K) MapDelta[I, K] {
+	return MapDiffBy(oldMap, newMap, func(a, b K) bool {
+		return a == b
+	})
+}
+
+// MapDiffBy returns entries added to, removed from and changed in the new map compared to the old one.
+// Values are compared with the provided equal function.
+func MapDiffBy[I comparable, K any](oldMap, newMap map[I]K, equal func(a, b K) bool) MapDelta[I, K] {
+	d := MapDelta[I, K]{
+		Added:   make(map[I]K),
+		Removed: make(map[I]K),
+		Changed: make(map[I]MapChange[K]),
+	}
+
+	for k, oldV := range oldMap {
+		newV, ok := newMap[k]
+		if !ok {
+			d.Removed[k] = oldV
+
+			continue
+		}
+
+		if !equal(oldV, newV) {
+			d.Changed[k] = MapChange[K]{Old: oldV, New: newV}
+		}
+	}
+
+	for k, newV := range newMap {
+		if _, ok := oldMap[k]; !ok {
+			d.Added[k] = newV
+		}
+	}
+
+	return d
+}
+
+// MapApplyDiff creates a copy of a given map with the delta applied:
+// removed keys are deleted, added and changed entries are set to their new values.
+func MapApplyDiff[I comparable, K any](arr map[I]K, d MapDelta[I, K]) map[I]K {
+	r := make(map[I]K, len(arr)+len(d.Added))
+
+	for k, v := range arr {
+		r[k] = v
+	}
+
+	for k := range d.Removed {
+		delete(r, k)
+	}
+
+	for k, v := range d.Added {
+		r[k] = v
+	}
+
+	for k, c := range d.Changed {
+		r[k] = c.New
+	}
+
+	return r
+}
+
+// MapMapValues creates a new map with the same keys populated with the results of calling a provided function
+// on every entry in the calling map.
+func MapMapValues[I comparable, K, T any](arr map[I]K, callback func(key I, value K) T) map[I]T {
+	r := make(map[I]T, len(arr))
+
+	for k, v := range arr {
+		r[k] = callback(k, v)
+	}
+
+	return r
+}
+
+// MapMapValuesErr creates a new map with the same keys populated with the results of calling a provided function
+// on every entry in the calling map.
+// Returns first error, if callback fails.
+func MapMapValuesErr[I comparable, K, T any](arr map[I]K, callback func(key I, value K) (T, error)) (map[I]T, error) {
+	r := make(map[I]T, len(arr))
+
+	for k, v := range arr {
+		res, err := callback(k, v)
+		if err != nil {
+			return nil, fmt.Errorf("callback: %w", err)
+		}
+
+		r[k] = res
+	}
+
+	return r, nil
+}
+
+// MapMapKeys creates a new map with the same values stored under keys returned by a provided function.
+// When several entries produce the same key, resolve is called with the key and both values,
+// and its result is kept. Entries are visited in map iteration order, so resolve should not depend on it.
+// If resolve is nil, ErrKeyCollision is returned instead.
+func MapMapKeys[I, T comparable, K any](
+	arr map[I]K,
+	callback func(key I, value K) T,
+	resolve func(key T, existing, incoming K) K,
+) (map[T]K, error) {
+	return MapMapKeysErr(arr, func(key I, value K) (T, error) {
+		return callback(key, value), nil
+	}, resolve)
+}
+
+// MapMapKeysErr creates a new map with the same values stored under keys returned by a provided function.
+// Collisions are handled the same way as in MapMapKeys.
+// Returns first error, if callback fails.
+func MapMapKeysErr[I, T comparable, K any](
+	arr map[I]K,
+	callback func(key I, value K) (T, error),
+	resolve func(key T, existing, incoming K) K,
+) (map[T]K, error) {
+	r := make(map[T]K, len(arr))
+
+	for k, v := range arr {
+		newKey, err := callback(k, v)
+		if err != nil {
+			return nil, fmt.Errorf("callback: %w", err)
+		}
+
+		existing, ok := r[newKey]
+		if !ok {
+			r[newKey] = v
+
+			continue
+		}
+
+		if resolve == nil {
+			return nil, fmt.Errorf("key %v: %w", newKey, ErrKeyCollision)
+		}
+
+		r[newKey] = resolve(newKey, existing, v)
+	}
+
+	return r, nil
+}
+
+// MapInvert creates a new map with keys and values swapped.
+// When several keys share the same value, resolve is called with the value and both keys,
+// and its result is kept. Entries are visited in map iteration order, so resolve should not depend on it.
+// If resolve is nil, ErrKeyCollision is returned instead. To keep all keys use MapInvertMulti.
+func MapInvert[I, K comparable](arr map[I]K, resolve func(value K, existing, incoming I) I) (map[K]I, error) {
+	r := make(map[K]I, len(arr))
+
+	for k, v := range arr {
+		existing, ok := r[v]
+		if !ok {
+			r[v] = k
+
+			continue
+		}
+
+		if resolve == nil {
+			return nil, fmt.Errorf("key %v: %w", v, ErrKeyCollision)
+		}
+
+		r[v] = resolve(v, existing, k)
+	}
+
+	return r, nil
+}
+
+// MapInvertMulti creates a new map from every value to all keys it is stored under.
+// The order of keys in every slice is not guaranteed.
+func MapInvertMulti[I, K comparable](arr map[I]K) map[K][]I {
+	r := make(map[K][]I, len(arr))
+
+	for k, v := range arr {
+		r[v] = append(r[v], k)
+	}
+
+	return r
+}
+
+// Pair is a key-value pair of a map.
+type Pair[I, K any] struct {
+	Key   I
+	Value K
+}
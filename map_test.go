@@ -1,7 +1,9 @@
 package arrays_test
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
 	"sort"
 	"testing"
 
@@ -294,3 +296,355 @@ func TestMapValues(t *testing.T) {
 		})
 	}
 }
+
+func TestMapDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		oldMap      map[string]int
+		newMap      map[string]int
+		wantAdded   map[string]int
+		wantRemoved map[string]int
+		wantChanged map[string]arrays.MapChange[int]
+	}{
+		{
+			name:        "added, removed and changed",
+			oldMap:      map[string]int{"a": 1, "b": 2, "c": 3},
+			newMap:      map[string]int{"a": 1, "b": 20, "d": 4},
+			wantAdded:   map[string]int{"d": 4},
+			wantRemoved: map[string]int{"c": 3},
+			wantChanged: map[string]arrays.MapChange[int]{"b": {Old: 2, New: 20}},
+		},
+		{
+			name:        "equal maps",
+			oldMap:      map[string]int{"a": 1},
+			newMap:      map[string]int{"a": 1},
+			wantAdded:   map[string]int{},
+			wantRemoved: map[string]int{},
+			wantChanged: map[string]arrays.MapChange[int]{},
+		},
+		{
+			name:        "nil old map",
+			oldMap:      nil,
+			newMap:      map[string]int{"a": 1},
+			wantAdded:   map[string]int{"a": 1},
+			wantRemoved: map[string]int{},
+			wantChanged: map[string]arrays.MapChange[int]{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := arrays.MapDiff(tt.oldMap, tt.newMap)
+
+			if !reflect.DeepEqual(got.Added, tt.wantAdded) {
+				t.Errorf("got added %v, want %v", got.Added, tt.wantAdded)
+			}
+
+			if !reflect.DeepEqual(got.Removed, tt.wantRemoved) {
+				t.Errorf("got removed %v, want %v", got.Removed, tt.wantRemoved)
+			}
+
+			if !reflect.DeepEqual(got.Changed, tt.wantChanged) {
+				t.Errorf("got changed %v, want %v", got.Changed, tt.wantChanged)
+			}
+
+			if got.Empty() != (len(tt.wantAdded)+len(tt.wantRemoved)+len(tt.wantChanged) == 0) {
+				t.Errorf("got empty %v", got.Empty())
+			}
+
+			applied := arrays.MapApplyDiff(tt.oldMap, got)
+			if len(applied) != len(tt.newMap) {
+				t.Errorf("applied %v, want %v", applied, tt.newMap)
+			}
+
+			for k, v := range tt.newMap {
+				if applied[k] != v {
+					t.Errorf("applied for key %s: got %v, want %v", k, applied[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestMapDiffBy(t *testing.T) {
+	t.Parallel()
+
+	oldMap := map[string][]string{"a": {"x"}, "b": {"y"}}
+	newMap := map[string][]string{"a": {"x"}, "b": {"y", "z"}}
+
+	got := arrays.MapDiffBy(oldMap, newMap, func(a, b []string) bool {
+		return reflect.DeepEqual(a, b)
+	})
+
+	if len(got.Added) != 0 || len(got.Removed) != 0 {
+		t.Errorf("got added %v, removed %v, want none", got.Added, got.Removed)
+	}
+
+	change, ok := got.Changed["b"]
+	if !ok || len(got.Changed) != 1 {
+		t.Fatalf("got changed %v, want only key b", got.Changed)
+	}
+
+	if len(change.Old) != 1 || len(change.New) != 2 {
+		t.Errorf("got change %v", change)
+	}
+}
+
+func TestMapApplyDiff(t *testing.T) {
+	t.Parallel()
+
+	arr := map[string]int{"a": 1, "b": 2}
+	d := arrays.MapDelta[string, int]{
+		Added:   map[string]int{"c": 3},
+		Removed: map[string]int{"a": 1},
+		Changed: map[string]arrays.MapChange[int]{"b": {Old: 2, New: 5}},
+	}
+
+	got := arrays.MapApplyDiff(arr, d)
+	want := map[string]int{"b": 5, "c": 3}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if len(arr) != 2 || arr["a"] != 1 || arr["b"] != 2 {
+		t.Errorf("source map was modified: %v", arr)
+	}
+}
+
+func TestMapMapValues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		arr      map[string]int
+		callback func(string, int) string
+		want     map[string]string
+	}{
+		{
+			name: "format values",
+			arr:  map[string]int{"a": 1, "b": 2},
+			callback: func(k string, v int) string {
+				return fmt.Sprintf("%s=%d", k, v)
+			},
+			want: map[string]string{"a": "a=1", "b": "b=2"},
+		},
+		{
+			name: "empty map",
+			arr:  map[string]int{},
+			callback: func(k string, v int) string {
+				return k
+			},
+			want: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := arrays.MapMapValues(tt.arr, tt.callback)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapMapValuesErr(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		arr       map[string]int
+		callback  func(string, int) (int, error)
+		want      map[string]int
+		wantError bool
+	}{
+		{
+			name: "successful conversion",
+			arr:  map[string]int{"a": 1, "b": 2},
+			callback: func(_ string, v int) (int, error) {
+				return v * 10, nil
+			},
+			want: map[string]int{"a": 10, "b": 20},
+		},
+		{
+			name: "callback returns error",
+			arr:  map[string]int{"a": 1, "b": 2},
+			callback: func(_ string, v int) (int, error) {
+				if v == 2 {
+					return 0, fmt.Errorf("error at value %d", v)
+				}
+				return v, nil
+			},
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := arrays.MapMapValuesErr(tt.arr, tt.callback)
+
+			if (err != nil) != tt.wantError {
+				t.Fatalf("MapMapValuesErr() error = %v, wantError %v", err, tt.wantError)
+			}
+
+			if !tt.wantError && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapMapKeys(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		arr       map[string]int
+		callback  func(string, int) string
+		resolve   func(string, int, int) int
+		want      map[string]int
+		wantError error
+	}{
+		{
+			name:     "prefix keys",
+			arr:      map[string]int{"a": 1, "b": 2},
+			callback: func(k string, _ int) string { return "x-" + k },
+			want:     map[string]int{"x-a": 1, "x-b": 2},
+		},
+		{
+			name:     "collision resolved",
+			arr:      map[string]int{"apple": 1, "avocado": 2, "banana": 3},
+			callback: func(k string, _ int) string { return k[:1] },
+			resolve: func(_ string, existing, incoming int) int {
+				return existing + incoming
+			},
+			want: map[string]int{"a": 3, "b": 3},
+		},
+		{
+			name:      "collision without resolver",
+			arr:       map[string]int{"apple": 1, "avocado": 2},
+			callback:  func(k string, _ int) string { return k[:1] },
+			wantError: arrays.ErrKeyCollision,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := arrays.MapMapKeys(tt.arr, tt.callback, tt.resolve)
+
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("MapMapKeys() error = %v, wantError %v", err, tt.wantError)
+			}
+
+			if tt.wantError == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapMapKeysErr(t *testing.T) {
+	t.Parallel()
+
+	errBadKey := errors.New("bad key")
+
+	_, err := arrays.MapMapKeysErr(map[string]int{"a": 1, "": 2}, func(k string, _ int) (string, error) {
+		if k == "" {
+			return "", errBadKey
+		}
+		return k, nil
+	}, nil)
+
+	if !errors.Is(err, errBadKey) {
+		t.Errorf("got error %v, want %v", err, errBadKey)
+	}
+
+	got, err := arrays.MapMapKeysErr(map[int]string{1: "a", 2: "b"}, func(k int, _ string) (int, error) {
+		return k * 2, nil
+	}, nil)
+	if err != nil {
+		t.Fatalf("MapMapKeysErr() error = %v", err)
+	}
+
+	want := map[int]string{2: "a", 4: "b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestMapInvert(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		arr       map[string]int
+		resolve   func(int, string, string) string
+		want      map[int]string
+		wantError error
+	}{
+		{
+			name: "invert unique values",
+			arr:  map[string]int{"a": 1, "b": 2},
+			want: map[int]string{1: "a", 2: "b"},
+		},
+		{
+			name: "collision resolved by smallest key",
+			arr:  map[string]int{"a": 1, "b": 1, "c": 2},
+			resolve: func(_ int, existing, incoming string) string {
+				if incoming < existing {
+					return incoming
+				}
+				return existing
+			},
+			want: map[int]string{1: "a", 2: "c"},
+		},
+		{
+			name:      "collision without resolver",
+			arr:       map[string]int{"a": 1, "b": 1},
+			wantError: arrays.ErrKeyCollision,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := arrays.MapInvert(tt.arr, tt.resolve)
+
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("MapInvert() error = %v, wantError %v", err, tt.wantError)
+			}
+
+			if tt.wantError == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapInvertMulti(t *testing.T) {
+	t.Parallel()
+
+	got := arrays.MapInvertMulti(map[string]int{"a": 1, "b": 1, "c": 2})
+
+	for _, keys := range got {
+		sort.Strings(keys)
+	}
+
+	want := map[int][]string{1: {"a", "b"}, 2: {"c"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
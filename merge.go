@@ -0,0 +1,175 @@
+package arrays
+
+import "reflect"
+
+// MergeFirstWins is a MapMerge resolver that keeps the value from the earliest map.
+func MergeFirstWins[I comparable, K any](_ I, existing, _ K) K {
+	return existing
+}
+
+// MergeLastWins is a MapMerge resolver that keeps the value from the latest map.
+func MergeLastWins[I comparable, K any](_ I, _, incoming K) K {
+	return incoming
+}
+
+// MapMerge creates a new map with all entries of the provided maps.
+// When a key is present in several maps, resolve is called with the key, the value merged so far
+// and the value from the next map, and its result is kept.
+// If resolve is nil, the value from the latest map wins.
+func MapMerge[I comparable, K any](resolve func(key I, existing, incoming K) K, arr ...map[I]K) map[I]K {
+	if resolve == nil {
+		resolve = MergeLastWins[I, K]
+	}
+
+	size := 0
+	for _, m := range arr {
+		size += len(m)
+	}
+
+	r := make(map[I]K, size)
+
+	for _, m := range arr {
+		for k, v := range m {
+			if existing, ok := r[k]; ok {
+				r[k] = resolve(k, existing, v)
+
+				continue
+			}
+
+			r[k] = v
+		}
+	}
+
+	return r
+}
+
+// SlicePolicy defines how MapDeepMerge combines two []any values stored under the same key.
+type SlicePolicy int
+
+const (
+	// SliceReplace replaces the existing slice with the incoming one.
+	SliceReplace SlicePolicy = iota
+	// SliceAppend appends the incoming slice to the existing one.
+	SliceAppend
+	// SliceAppendUniq appends the incoming slice to the existing one and drops duplicates
+	// like ArrayUniq does, but keeps the order of first occurrences.
+	// Non-comparable elements are always kept.
+	SliceAppendUniq
+)
+
+// MapDeepMerge creates a new map with all entries of the provided maps merged recursively.
+// Nested map[string]any values are merged key by key, []any values are combined according to policy,
+// any other value from a later map replaces the earlier one.
+// Provided maps are never modified, nested maps and slices of the result are copies.
+func MapDeepMerge(policy SlicePolicy, arr ...map[string]any) map[string]any {
+	r := make(map[string]any)
+
+	for _, m := range arr {
+		deepMergeInto(r, m, policy)
+	}
+
+	return r
+}
+
+func deepMergeInto(dst, src map[string]any, policy SlicePolicy) {
+	for k, v := range src {
+		existing, ok := dst[k]
+		if !ok {
+			dst[k] = deepCopyValue(v)
+
+			continue
+		}
+
+		switch incoming := v.(type) {
+		case map[string]any:
+			if existingMap, ok := existing.(map[string]any); ok {
+				deepMergeInto(existingMap, incoming, policy)
+
+				continue
+			}
+		case []any:
+			if existingSlice, ok := existing.([]any); ok {
+				dst[k] = mergeSlices(existingSlice, incoming, policy)
+
+				continue
+			}
+		}
+
+		dst[k] = deepCopyValue(v)
+	}
+}
+
+// hashable reports whether the value can be used as a map key. Comparable types are not enough:
+// a struct, array or interface holding a slice, map or function panics when hashed.
+func hashable(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Func:
+		return false
+	case reflect.Interface:
+		return v.IsNil() || hashable(v.Elem())
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if !hashable(v.Index(i)) {
+				return false
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !hashable(v.Field(i)) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func mergeSlices(existing, incoming []any, policy SlicePolicy) []any {
+	switch policy {
+	case SliceAppend:
+		return append(existing, deepCopyValue(incoming).([]any)...)
+	case SliceAppendUniq:
+		r := make([]any, 0, len(existing)+len(incoming))
+		seen := make(map[any]bool, len(existing)+len(incoming))
+
+		for _, v := range ArrayConcat(existing, incoming) {
+			if !hashable(reflect.ValueOf(v)) {
+				r = append(r, deepCopyValue(v))
+
+				continue
+			}
+
+			if seen[v] {
+				continue
+			}
+
+			seen[v] = true
+			r = append(r, deepCopyValue(v))
+		}
+
+		return r
+	default:
+		return deepCopyValue(incoming).([]any)
+	}
+}
+
+func deepCopyValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		r := make(map[string]any, len(val))
+		for k, item := range val {
+			r[k] = deepCopyValue(item)
+		}
+
+		return r
+	case []any:
+		r := make([]any, len(val))
+		for i, item := range val {
+			r[i] = deepCopyValue(item)
+		}
+
+		return r
+	default:
+		return v
+	}
+}
@@ -0,0 +1,145 @@
+package arrays
+
+type mergeCursor struct {
+	arr int
+	pos int
+}
+
+func mergeSorted[T any](cmp func(a, b T) int, uniq bool, arrs [][]T) []T {
+	size := 0
+	for _, arr := range arrs {
+		size += len(arr)
+	}
+
+	r := make([]T, 0, size)
+
+	cursors := make([]mergeCursor, 0, len(arrs))
+	for i, arr := range arrs {
+		if len(arr) > 0 {
+			cursors = append(cursors, mergeCursor{arr: i})
+		}
+	}
+
+	h := NewHeapFrom(cursors, func(a, b mergeCursor) bool {
+		if c := cmp(arrs[a.arr][a.pos], arrs[b.arr][b.pos]); c != 0 {
+			return c < 0
+		}
+
+		return a.arr < b.arr
+	})
+
+	for h.Len() > 0 {
+		c, _ := h.Peek()
+		v := arrs[c.arr][c.pos]
+
+		if !uniq || len(r) == 0 || cmp(r[len(r)-1], v) != 0 {
+			r = append(r, v)
+		}
+
+		if c.pos+1 < len(arrs[c.arr]) {
+			h.Update(0, mergeCursor{arr: c.arr, pos: c.pos + 1})
+		} else {
+			h.Pop()
+		}
+	}
+
+	return r
+}
+
+// ArrayMergeSorted merges arrays sorted in ascending order into a new sorted array in O(n log k),
+// where k is the number of arrays. Equal elements are taken from earlier arrays first.
+func ArrayMergeSorted[T Ordered](arrs ...[]T) []T {
+	return mergeSorted(compareOrdered[T], false, arrs)
+}
+
+// ArrayMergeSortedFunc merges arrays sorted by the cmp function into a new sorted array in O(n log k).
+// Equal elements are taken from earlier arrays first.
+func ArrayMergeSortedFunc[T any](cmp func(a, b T) int, arrs ...[]T) []T {
+	return mergeSorted(cmp, false, arrs)
+}
+
+// ArrayMergeSortedUniq merges arrays sorted in ascending order into a new sorted array without duplicates.
+// Of equal elements the one from the earliest array is kept.
+func ArrayMergeSortedUniq[T Ordered](arrs ...[]T) []T {
+	return mergeSorted(compareOrdered[T], true, arrs)
+}
+
+// ArrayMergeSortedUniqFunc merges arrays sorted by the cmp function into a new sorted array without duplicates.
+// Of equal elements the one from the earliest array is kept.
+func ArrayMergeSortedUniqFunc[T any](cmp func(a, b T) int, arrs ...[]T) []T {
+	return mergeSorted(cmp, true, arrs)
+}
+
+// sortedSetOp walks two sorted arrays in one pass and keeps elements selected by the flags:
+// onlyA and onlyB for unmatched elements, both for pairs of equal elements (the one from a is kept).
+func sortedSetOp[T any](a, b []T, cmp func(a, b T) int, onlyA, onlyB, both bool) []T {
+	r := make([]T, 0)
+	i, j := 0, 0
+
+	for i < len(a) && j < len(b) {
+		switch c := cmp(a[i], b[j]); {
+		case c < 0:
+			if onlyA {
+				r = append(r, a[i])
+			}
+
+			i++
+		case c > 0:
+			if onlyB {
+				r = append(r, b[j])
+			}
+
+			j++
+		default:
+			if both {
+				r = append(r, a[i])
+			}
+
+			i++
+			j++
+		}
+	}
+
+	if onlyA {
+		r = append(r, a[i:]...)
+	}
+
+	if onlyB {
+		r = append(r, b[j:]...)
+	}
+
+	return r
+}
+
+// ArraySortedIntersect returns elements present in both arrays sorted in ascending order, in O(n+m).
+// Duplicates are matched one to one, so an element is kept as many times as it appears in both arrays.
+func ArraySortedIntersect[T Ordered](a, b []T) []T {
+	return sortedSetOp(a, b, compareOrdered[T], false, false, true)
+}
+
+// ArraySortedIntersectFunc is ArraySortedIntersect for arrays sorted by the cmp function.
+func ArraySortedIntersectFunc[T any](a, b []T, cmp func(a, b T) int) []T {
+	return sortedSetOp(a, b, cmp, false, false, true)
+}
+
+// ArraySortedUnion returns elements present in any of arrays sorted in ascending order, in O(n+m).
+// Duplicates are matched one to one, so an element is kept as many times as it appears in either array at most.
+func ArraySortedUnion[T Ordered](a, b []T) []T {
+	return sortedSetOp(a, b, compareOrdered[T], true, true, true)
+}
+
+// ArraySortedUnionFunc is ArraySortedUnion for arrays sorted by the cmp function.
+func ArraySortedUnionFunc[T any](a, b []T, cmp func(a, b T) int) []T {
+	return sortedSetOp(a, b, cmp, true, true, true)
+}
+
+// ArraySortedDifference returns elements of a that are not present in b, sorted in ascending order, in O(n+m).
+// Duplicates are matched one to one, so every element of b removes at most one equal element of a.
+func ArraySortedDifference[T Ordered](a, b []T) []T {
+	return sortedSetOp(a, b, compareOrdered[T], true, false, false)
+}
+
+// ArraySortedDifferenceFunc is ArraySortedDifference for arrays sorted by the cmp function.
+func ArraySortedDifferenceFunc[T any](a, b []T, cmp func(a, b T) int) []T {
+	return sortedSetOp(a, b, cmp, true, false, false)
+}
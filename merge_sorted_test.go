@@ -0,0 +1,162 @@
+package arrays_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+func TestArrayMergeSorted(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		arrs     [][]int
+		want     []int
+		wantUniq []int
+	}{
+		{
+			name:     "three arrays",
+			arrs:     [][]int{{1, 4, 7}, {2, 4, 8}, {0, 9}},
+			want:     []int{0, 1, 2, 4, 4, 7, 8, 9},
+			wantUniq: []int{0, 1, 2, 4, 7, 8, 9},
+		},
+		{
+			name:     "duplicates inside an array",
+			arrs:     [][]int{{1, 1, 2}, {1}},
+			want:     []int{1, 1, 1, 2},
+			wantUniq: []int{1, 2},
+		},
+		{
+			name:     "empty arrays",
+			arrs:     [][]int{{}, nil, {3}},
+			want:     []int{3},
+			wantUniq: []int{3},
+		},
+		{
+			name:     "no arrays",
+			arrs:     nil,
+			want:     []int{},
+			wantUniq: []int{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := arrays.ArrayMergeSorted(tt.arrs...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ArrayMergeSorted() = %v, want %v", got, tt.want)
+			}
+
+			if got := arrays.ArrayMergeSortedUniq(tt.arrs...); !reflect.DeepEqual(got, tt.wantUniq) {
+				t.Errorf("ArrayMergeSortedUniq() = %v, want %v", got, tt.wantUniq)
+			}
+		})
+	}
+}
+
+func TestArrayMergeSortedFunc(t *testing.T) {
+	t.Parallel()
+
+	type page struct {
+		id    int
+		shard string
+	}
+
+	cmp := func(a, b page) int { return a.id - b.id }
+	shardA := []page{{id: 1, shard: "a"}, {id: 3, shard: "a"}}
+	shardB := []page{{id: 1, shard: "b"}, {id: 2, shard: "b"}}
+
+	got := arrays.ArrayMergeSortedFunc(cmp, shardA, shardB)
+	want := []page{{id: 1, shard: "a"}, {id: 1, shard: "b"}, {id: 2, shard: "b"}, {id: 3, shard: "a"}}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	gotUniq := arrays.ArrayMergeSortedUniqFunc(cmp, shardB, shardA)
+	wantUniq := []page{{id: 1, shard: "b"}, {id: 2, shard: "b"}, {id: 3, shard: "a"}}
+
+	if !reflect.DeepEqual(gotUniq, wantUniq) {
+		t.Errorf("got %v, want %v", gotUniq, wantUniq)
+	}
+}
+
+func TestArraySortedSetOperations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		a             []int
+		b             []int
+		wantIntersect []int
+		wantUnion     []int
+		wantDiff      []int
+	}{
+		{
+			name:          "overlapping sets",
+			a:             []int{1, 3, 5, 7},
+			b:             []int{3, 4, 5, 8},
+			wantIntersect: []int{3, 5},
+			wantUnion:     []int{1, 3, 4, 5, 7, 8},
+			wantDiff:      []int{1, 7},
+		},
+		{
+			name:          "duplicates are matched one to one",
+			a:             []int{1, 1, 1, 2},
+			b:             []int{1, 2, 2},
+			wantIntersect: []int{1, 2},
+			wantUnion:     []int{1, 1, 1, 2, 2},
+			wantDiff:      []int{1, 1},
+		},
+		{
+			name:          "empty b",
+			a:             []int{1, 2},
+			b:             []int{},
+			wantIntersect: []int{},
+			wantUnion:     []int{1, 2},
+			wantDiff:      []int{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := arrays.ArraySortedIntersect(tt.a, tt.b); !reflect.DeepEqual(got, tt.wantIntersect) {
+				t.Errorf("ArraySortedIntersect() = %v, want %v", got, tt.wantIntersect)
+			}
+
+			if got := arrays.ArraySortedUnion(tt.a, tt.b); !reflect.DeepEqual(got, tt.wantUnion) {
+				t.Errorf("ArraySortedUnion() = %v, want %v", got, tt.wantUnion)
+			}
+
+			if got := arrays.ArraySortedDifference(tt.a, tt.b); !reflect.DeepEqual(got, tt.wantDiff) {
+				t.Errorf("ArraySortedDifference() = %v, want %v", got, tt.wantDiff)
+			}
+		})
+	}
+}
+
+func TestArraySortedSetOperationsFunc(t *testing.T) {
+	t.Parallel()
+
+	cmp := func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) }
+	a := []string{"Apple", "banana", "Cherry"}
+	b := []string{"apple", "cherry", "date"}
+
+	if got := arrays.ArraySortedIntersectFunc(a, b, cmp); !reflect.DeepEqual(got, []string{"Apple", "Cherry"}) {
+		t.Errorf("ArraySortedIntersectFunc() = %v", got)
+	}
+
+	if got := arrays.ArraySortedUnionFunc(a, b, cmp); !reflect.DeepEqual(got, []string{"Apple", "banana", "Cherry", "date"}) {
+		t.Errorf("ArraySortedUnionFunc() = %v", got)
+	}
+
+	if got := arrays.ArraySortedDifferenceFunc(a, b, cmp); !reflect.DeepEqual(got, []string{"banana"}) {
+		t.Errorf("ArraySortedDifferenceFunc() = %v", got)
+	}
+}
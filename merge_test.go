@@ -0,0 +1,182 @@
+package arrays_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+func TestMapMerge(t *testing.T) {
+	t.Parallel()
+
+	first := map[string]int{"a": 1, "b": 2}
+	second := map[string]int{"b": 20, "c": 30}
+	third := map[string]int{"c": 300}
+
+	tests := []struct {
+		name    string
+		resolve func(string, int, int) int
+		arr     []map[string]int
+		want    map[string]int
+	}{
+		{
+			name:    "last wins",
+			resolve: arrays.MergeLastWins[string, int],
+			arr:     []map[string]int{first, second, third},
+			want:    map[string]int{"a": 1, "b": 20, "c": 300},
+		},
+		{
+			name:    "nil resolver defaults to last wins",
+			resolve: nil,
+			arr:     []map[string]int{first, second},
+			want:    map[string]int{"a": 1, "b": 20, "c": 30},
+		},
+		{
+			name:    "first wins",
+			resolve: arrays.MergeFirstWins[string, int],
+			arr:     []map[string]int{first, second, third},
+			want:    map[string]int{"a": 1, "b": 2, "c": 30},
+		},
+		{
+			name: "custom resolver",
+			resolve: func(_ string, existing, incoming int) int {
+				return existing + incoming
+			},
+			arr:  []map[string]int{first, second, third},
+			want: map[string]int{"a": 1, "b": 22, "c": 330},
+		},
+		{
+			name:    "no maps",
+			resolve: nil,
+			arr:     nil,
+			want:    map[string]int{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := arrays.MapMerge(tt.resolve, tt.arr...)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapDeepMerge(t *testing.T) {
+	t.Parallel()
+
+	base := func() map[string]any {
+		return map[string]any{
+			"name": "base",
+			"db": map[string]any{
+				"host": "localhost",
+				"port": 5432,
+			},
+			"tags": []any{"a", "b"},
+		}
+	}
+	override := func() map[string]any {
+		return map[string]any{
+			"db": map[string]any{
+				"port": 6432,
+				"ssl":  true,
+			},
+			"tags": []any{"b", "c"},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		policy arrays.SlicePolicy
+		want   map[string]any
+	}{
+		{
+			name:   "replace slices",
+			policy: arrays.SliceReplace,
+			want: map[string]any{
+				"name": "base",
+				"db":   map[string]any{"host": "localhost", "port": 6432, "ssl": true},
+				"tags": []any{"b", "c"},
+			},
+		},
+		{
+			name:   "append slices",
+			policy: arrays.SliceAppend,
+			want: map[string]any{
+				"name": "base",
+				"db":   map[string]any{"host": "localhost", "port": 6432, "ssl": true},
+				"tags": []any{"a", "b", "b", "c"},
+			},
+		},
+		{
+			name:   "append unique slices",
+			policy: arrays.SliceAppendUniq,
+			want: map[string]any{
+				"name": "base",
+				"db":   map[string]any{"host": "localhost", "port": 6432, "ssl": true},
+				"tags": []any{"a", "b", "c"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, o := base(), override()
+			got := arrays.MapDeepMerge(tt.policy, b, o)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+
+			if !reflect.DeepEqual(b, base()) || !reflect.DeepEqual(o, override()) {
+				t.Errorf("source maps were modified: %v, %v", b, o)
+			}
+		})
+	}
+}
+
+func TestMapDeepMergeTypeMismatch(t *testing.T) {
+	t.Parallel()
+
+	got := arrays.MapDeepMerge(arrays.SliceAppendUniq,
+		map[string]any{"a": map[string]any{"b": 1}, "c": []any{map[string]any{"x": 1}}},
+		map[string]any{"a": "scalar", "c": []any{map[string]any{"x": 1}}},
+	)
+
+	want := map[string]any{
+		"a": "scalar",
+		"c": []any{map[string]any{"x": 1}, map[string]any{"x": 1}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestMapDeepMergeUnhashableValues(t *testing.T) {
+	t.Parallel()
+
+	type wrapper struct {
+		X any
+	}
+
+	got := arrays.MapDeepMerge(arrays.SliceAppendUniq,
+		map[string]any{"c": []any{wrapper{X: []int{1}}, [1]any{map[string]int{}}, wrapper{X: 1}}},
+		map[string]any{"c": []any{wrapper{X: []int{1}}, wrapper{X: 1}}},
+	)
+
+	want := map[string]any{
+		"c": []any{wrapper{X: []int{1}}, [1]any{map[string]int{}}, wrapper{X: 1}, wrapper{X: []int{1}}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,178 @@
+package arrays
+
+// MultiMapMode defines how MultiMap stores values of a key.
+type MultiMapMode int
+
+const (
+	// MultiMapList keeps all added values of a key, including duplicates, in insertion order.
+	MultiMapList MultiMapMode = iota
+	// MultiMapSet keeps only distinct values of a key, in order of first insertion.
+	MultiMapSet
+)
+
+// MultiMap is a map from a key to several values.
+// Keys without values are removed. It's not safe for concurrent use.
+type MultiMap[I, K comparable] struct {
+	m    map[I][]K
+	mode MultiMapMode
+	size int
+	// index holds the values of every key in MultiMapSet mode, so adding and checking a value take O(1).
+	index map[I]map[K]struct{}
+}
+
+// NewMultiMap creates an empty MultiMap with the provided mode.
+func NewMultiMap[I, K comparable](mode MultiMapMode) *MultiMap[I, K] {
+	m := &MultiMap[I, K]{
+		m:    make(map[I][]K),
+		mode: mode,
+	}
+
+	if mode == MultiMapSet {
+		m.index = make(map[I]map[K]struct{})
+	}
+
+	return m
+}
+
+// NewMultiMapFrom creates a MultiMap populated with the values of a plain map, such as a grouping result.
+func NewMultiMapFrom[I, K comparable](arr map[I][]K, mode MultiMapMode) *MultiMap[I, K] {
+	m := NewMultiMap[I, K](mode)
+
+	for k, values := range arr {
+		m.Add(k, values...)
+	}
+
+	return m
+}
+
+// NewMultiMapFromPairs creates a MultiMap populated with the pairs in order.
+func NewMultiMapFromPairs[I, K comparable](pairs []Pair[I, K], mode MultiMapMode) *MultiMap[I, K] {
+	m := NewMultiMap[I, K](mode)
+
+	for _, p := range pairs {
+		m.Add(p.Key, p.Value)
+	}
+
+	return m
+}
+
+// Add adds values to the key. In MultiMapSet mode values already present are skipped.
+func (m *MultiMap[I, K]) Add(key I, values ...K) {
+	for _, v := range values {
+		if m.index != nil {
+			set, ok := m.index[key]
+			if !ok {
+				set = make(map[K]struct{})
+				m.index[key] = set
+			}
+
+			if _, ok := set[v]; ok {
+				continue
+			}
+
+			set[v] = struct{}{}
+		}
+
+		m.m[key] = append(m.m[key], v)
+		m.size++
+	}
+}
+
+// Remove removes the first occurrence of the value from the key, returns false if it wasn't found.
+func (m *MultiMap[I, K]) Remove(key I, value K) bool {
+	values := m.m[key]
+
+	i, ok := ArrayFindIndex(values, func(_ int, v K) bool {
+		return v == value
+	})
+	if !ok {
+		return false
+	}
+
+	m.size--
+
+	if m.index != nil {
+		delete(m.index[key], value)
+	}
+
+	if len(values) == 1 {
+		delete(m.m, key)
+		delete(m.index, key)
+
+		return true
+	}
+
+	r := make([]K, 0, len(values)-1)
+	m.m[key] = append(append(r, values[:i]...), values[i+1:]...)
+
+	return true
+}
+
+// RemoveAll removes the key with all its values, returns the number of removed values.
+func (m *MultiMap[I, K]) RemoveAll(key I) int {
+	n := len(m.m[key])
+	m.size -= n
+	delete(m.m, key)
+	delete(m.index, key)
+
+	return n
+}
+
+// Get returns a copy of the values of the key.
+func (m *MultiMap[I, K]) Get(key I) []K {
+	r := make([]K, len(m.m[key]))
+	copy(r, m.m[key])
+
+	return r
+}
+
+// Contains reports whether the value is stored for the key.
+// It takes O(1) in MultiMapSet mode and is linear in the number of values of the key in MultiMapList mode.
+func (m *MultiMap[I, K]) Contains(key I, value K) bool {
+	if m.index != nil {
+		_, ok := m.index[key][value]
+
+		return ok
+	}
+
+	return ArrayContains(m.m[key], value)
+}
+
+// Count returns the number of values of the key.
+func (m *MultiMap[I, K]) Count(key I) int {
+	return len(m.m[key])
+}
+
+// Len returns the total number of values of all keys.
+func (m *MultiMap[I, K]) Len() int {
+	return m.size
+}
+
+// Keys returns all keys that have values.
+func (m *MultiMap[I, K]) Keys() []I {
+	return MapKeys(m.m)
+}
+
+// ToMap returns a copy of the contents as a plain map.
+func (m *MultiMap[I, K]) ToMap() map[I][]K {
+	r := make(map[I][]K, len(m.m))
+
+	for k := range m.m {
+		r[k] = m.Get(k)
+	}
+
+	return r
+}
+
+// Pairs returns all key-value pairs. Values of a key keep their order, the order of keys is not guaranteed.
+func (m *MultiMap[I, K]) Pairs() []Pair[I, K] {
+	r := make([]Pair[I, K], 0, m.size)
+
+	for k, values := range m.m {
+		for _, v := range values {
+			r = append(r, Pair[I, K]{Key: k, Value: v})
+		}
+	}
+
+	return r
+}
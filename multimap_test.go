@@ -0,0 +1,166 @@
+package arrays_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+func TestMultiMapAdd(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		mode    arrays.MultiMapMode
+		want    map[string][]int
+		wantLen int
+	}{
+		{
+			name:    "list keeps duplicates",
+			mode:    arrays.MultiMapList,
+			want:    map[string][]int{"a": {1, 2, 1}, "b": {3}},
+			wantLen: 4,
+		},
+		{
+			name:    "set drops duplicates",
+			mode:    arrays.MultiMapSet,
+			want:    map[string][]int{"a": {1, 2}, "b": {3}},
+			wantLen: 3,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := arrays.NewMultiMap[string, int](tt.mode)
+			m.Add("a", 1, 2)
+			m.Add("a", 1)
+			m.Add("b", 3)
+
+			if got := m.ToMap(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+
+			if m.Len() != tt.wantLen {
+				t.Errorf("Len() = %d, want %d", m.Len(), tt.wantLen)
+			}
+
+			if m.Count("a") != len(tt.want["a"]) || m.Count("missing") != 0 {
+				t.Errorf("Count(a) = %d, want %d", m.Count("a"), len(tt.want["a"]))
+			}
+		})
+	}
+}
+
+func TestMultiMapRemove(t *testing.T) {
+	t.Parallel()
+
+	m := arrays.NewMultiMapFrom(map[string][]int{"a": {1, 2, 1}, "b": {3}}, arrays.MultiMapList)
+
+	if !m.Remove("a", 1) {
+		t.Fatal("Remove(a, 1) = false")
+	}
+
+	if got := m.Get("a"); !reflect.DeepEqual(got, []int{2, 1}) {
+		t.Errorf("Get(a) = %v, want [2 1]", got)
+	}
+
+	if m.Remove("a", 5) || m.Remove("missing", 1) {
+		t.Error("removed missing values")
+	}
+
+	if !m.Remove("b", 3) || m.Contains("b", 3) {
+		t.Error("Remove(b, 3) did not remove the value")
+	}
+
+	keys := m.Keys()
+	if !reflect.DeepEqual(keys, []string{"a"}) {
+		t.Errorf("Keys() = %v, want [a]", keys)
+	}
+
+	if n := m.RemoveAll("a"); n != 2 || m.Len() != 0 {
+		t.Errorf("RemoveAll(a) = %d, Len() = %d, want 2, 0", n, m.Len())
+	}
+}
+
+func TestMultiMapSetRemove(t *testing.T) {
+	t.Parallel()
+
+	m := arrays.NewMultiMap[string, int](arrays.MultiMapSet)
+	m.Add("a", 1, 2, 3)
+
+	if !m.Remove("a", 2) || m.Contains("a", 2) {
+		t.Fatal("Remove(a, 2) did not remove the value")
+	}
+
+	// A removed value can be added again and goes to the end.
+	m.Add("a", 2, 1)
+
+	if got := m.Get("a"); !reflect.DeepEqual(got, []int{1, 3, 2}) {
+		t.Errorf("Get(a) = %v, want [1 3 2]", got)
+	}
+
+	m.RemoveAll("a")
+	m.Add("a", 3)
+
+	if got := m.Get("a"); !reflect.DeepEqual(got, []int{3}) || !m.Contains("a", 3) || m.Contains("a", 1) {
+		t.Errorf("Get(a) = %v after RemoveAll, want [3]", got)
+	}
+}
+
+func BenchmarkMultiMapSetAdd(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		m := arrays.NewMultiMap[int, int](arrays.MultiMapSet)
+		for v := 0; v < 10_000; v++ {
+			m.Add(0, v)
+		}
+	}
+}
+
+func TestMultiMapGetReturnsCopy(t *testing.T) {
+	t.Parallel()
+
+	m := arrays.NewMultiMap[string, int](arrays.MultiMapList)
+	m.Add("a", 1)
+
+	got := m.Get("a")
+	got[0] = 100
+
+	if !m.Contains("a", 1) {
+		t.Error("Get() result shares memory with the map")
+	}
+}
+
+func TestMultiMapPairs(t *testing.T) {
+	t.Parallel()
+
+	pairs := []arrays.Pair[string, int]{
+		{Key: "a", Value: 1},
+		{Key: "b", Value: 2},
+		{Key: "a", Value: 3},
+		{Key: "a", Value: 3},
+	}
+
+	m := arrays.NewMultiMapFromPairs(pairs, arrays.MultiMapSet)
+
+	got := m.Pairs()
+	sort.Slice(got, func(i, j int) bool {
+		if got[i].Key != got[j].Key {
+			return got[i].Key < got[j].Key
+		}
+		return got[i].Value < got[j].Value
+	})
+
+	want := []arrays.Pair[string, int]{
+		{Key: "a", Value: 1},
+		{Key: "a", Value: 3},
+		{Key: "b", Value: 2},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
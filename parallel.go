@@ -0,0 +1,142 @@
+package arrays
+
+import (
+	"sync"
+)
+
+// chunkBounds splits n elements into at most parts contiguous chunks of almost equal size.
+func chunkBounds(n, parts int) [][2]int {
+	if parts > n {
+		parts = n
+	}
+
+	r := make([][2]int, 0, parts)
+
+	for i := 0; i < parts; i++ {
+		r = append(r, [2]int{i * n / parts, (i + 1) * n / parts})
+	}
+
+	return r
+}
+
+// ArrayParallelReduce reduces the array to a single value using several goroutines.
+// The array is split into contiguous chunks, every chunk is reduced with the reducer starting from initial,
+// then partial results are combined with the combiner in the order of chunks.
+// Initial must be an identity value for the combiner, e.g. 0 for a sum,
+// and the combiner must be associative for the result to match a sequential reduce.
+// Accepts WithParallelism and WithHooks.
+func ArrayParallelReduce[I, T any](
+	arr []I,
+	initial T,
+	reducer func(acc T, value I) T,
+	combiner func(a, b T) T,
+	opts ...ProcessOption,
+) T {
+	c := newProcessConfig(opts)
+	start := c.begin(len(arr))
+
+	chunks := chunkBounds(len(arr), c.parallelism)
+	if len(chunks) == 0 {
+		c.finish(start, nil)
+
+		return initial
+	}
+
+	partials := make([]T, len(chunks))
+
+	var wg sync.WaitGroup
+
+	wg.Add(len(chunks))
+
+	for i, bounds := range chunks {
+		go func(i, offset int, part []I) {
+			defer wg.Done()
+
+			acc := initial
+			for j, v := range part {
+				elementStart := c.elementStart(offset + j)
+				acc = reducer(acc, v)
+				c.elementDone(offset+j, elementStart, nil)
+			}
+
+			partials[i] = acc
+		}(i, bounds[0], arr[bounds[0]:bounds[1]])
+	}
+
+	wg.Wait()
+	c.finish(start, nil)
+
+	r := partials[0]
+	for _, p := range partials[1:] {
+		r = combiner(r, p)
+	}
+
+	return r
+}
+
+// ArrayParallelProcessErr creates a new array populated with the results of calling a provided function
+// on every element in the calling array using several goroutines.
+// The order of results matches the order of elements.
+// Returns first error, if callback fails, elements that haven't been started by then are skipped.
+// Accepts WithParallelism, WithContext, WithRetry, WithRateLimit, WithProcessClock, WithSleeper and WithHooks.
+func ArrayParallelProcessErr[I, T any](arr []I, callback func(value I) (T, error), opts ...ProcessOption) ([]T, error) {
+	c := newProcessConfig(opts)
+	start := c.begin(len(arr))
+
+	workers := c.parallelism
+	if workers > len(arr) {
+		workers = len(arr)
+	}
+
+	r := make([]T, len(arr))
+	indexes := make(chan int)
+	stop := make(chan struct{})
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+
+	wg.Add(workers)
+
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				res, err := processElement(c, i, arr[i], callback)
+				if err != nil {
+					once.Do(func() {
+						firstErr = err
+						close(stop)
+					})
+
+					return
+				}
+
+				r[i] = res
+			}
+		}()
+	}
+
+feed:
+	for i := range arr {
+		select {
+		case <-stop:
+			break feed
+		case indexes <- i:
+		}
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	c.finish(start, firstErr)
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return r, nil
+}
@@ -0,0 +1,89 @@
+package arrays_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+func TestArrayParallelReduce(t *testing.T) {
+	t.Parallel()
+
+	numbers := make([]int, 10001)
+	for i := range numbers {
+		numbers[i] = i
+	}
+
+	sum := func(acc, v int) int { return acc + v }
+
+	tests := []struct {
+		name string
+		arr  []int
+		opts []arrays.ProcessOption
+		want int
+	}{
+		{
+			name: "default parallelism",
+			arr:  numbers,
+			want: 50005000,
+		},
+		{
+			name: "single goroutine",
+			arr:  numbers,
+			opts: []arrays.ProcessOption{arrays.WithParallelism(1)},
+			want: 50005000,
+		},
+		{
+			name: "more goroutines than elements",
+			arr:  []int{1, 2, 3},
+			opts: []arrays.ProcessOption{arrays.WithParallelism(16)},
+			want: 6,
+		},
+		{
+			name: "invalid parallelism is ignored",
+			arr:  []int{1, 2, 3},
+			opts: []arrays.ProcessOption{arrays.WithParallelism(0)},
+			want: 6,
+		},
+		{
+			name: "empty array",
+			arr:  []int{},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := arrays.ArrayParallelReduce(tt.arr, 0, sum, sum, tt.opts...)
+
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArrayParallelReduceOrder(t *testing.T) {
+	t.Parallel()
+
+	arr := make([]int, 100)
+	want := ""
+
+	for i := range arr {
+		arr[i] = i
+		want += strconv.Itoa(i) + ","
+	}
+
+	got := arrays.ArrayParallelReduce(arr, "",
+		func(acc string, v int) string { return acc + strconv.Itoa(v) + "," },
+		func(a, b string) string { return a + b },
+		arrays.WithParallelism(7),
+	)
+
+	if got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
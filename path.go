@@ -0,0 +1,283 @@
+package arrays
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PathSeparator separates segments of dotted paths and flattened keys.
+const PathSeparator = "."
+
+var (
+	// ErrPathNotFound is returned when a path doesn't exist in a nested map.
+	ErrPathNotFound = errors.New("path not found")
+	// ErrPathType is returned when a value on a path has an unexpected type.
+	ErrPathType = errors.New("unexpected type on path")
+)
+
+// Path is a path in a nested map: either a dotted string like "a.b.0" or a slice of segments.
+// Segments addressing []any values must be numeric indexes.
+type Path interface {
+	string | []string
+}
+
+func pathSegments[P Path](path P) []string {
+	switch p := any(path).(type) {
+	case string:
+		if p == "" {
+			return []string{}
+		}
+
+		return strings.Split(p, PathSeparator)
+	case []string:
+		return p
+	default:
+		return nil
+	}
+}
+
+func pathError(segments []string, err error) error {
+	return fmt.Errorf("path %q: %w", strings.Join(segments, PathSeparator), err)
+}
+
+func sliceIndex(arr []any, segment string) (int, bool) {
+	i, err := strconv.Atoi(segment)
+	if err != nil || i < 0 || i >= len(arr) {
+		return 0, false
+	}
+
+	return i, true
+}
+
+// MapGetPath returns the value stored on the path in a nested map.
+// Returns ErrPathNotFound if any segment of the path is missing.
+func MapGetPath[P Path](arr map[string]any, path P) (any, error) {
+	segments := pathSegments(path)
+
+	var cur any = arr
+
+	for i, segment := range segments {
+		switch node := cur.(type) {
+		case map[string]any:
+			v, ok := node[segment]
+			if !ok {
+				return nil, pathError(segments[:i+1], ErrPathNotFound)
+			}
+
+			cur = v
+		case []any:
+			idx, ok := sliceIndex(node, segment)
+			if !ok {
+				return nil, pathError(segments[:i+1], ErrPathNotFound)
+			}
+
+			cur = node[idx]
+		default:
+			return nil, pathError(segments[:i+1], ErrPathNotFound)
+		}
+	}
+
+	return cur, nil
+}
+
+// MapGetPathAs returns the value stored on the path in a nested map converted to T.
+// Returns ErrPathNotFound if the path is missing and ErrPathType if the value is not a T.
+func MapGetPathAs[T any, P Path](arr map[string]any, path P) (T, error) {
+	v, err := MapGetPath(arr, path)
+	if err != nil {
+		return *new(T), err
+	}
+
+	r, ok := v.(T)
+	if !ok {
+		return *new(T), pathError(pathSegments(path), fmt.Errorf("%w: %T is not %v", ErrPathType, v, reflect.TypeOf((*T)(nil)).Elem()))
+	}
+
+	return r, nil
+}
+
+// MapSetPath stores the value on the path in a nested map.
+// Missing intermediate maps are created, indexes into []any must already exist.
+// Returns ErrPathType if an intermediate value is neither a map[string]any nor a []any.
+func MapSetPath[P Path](arr map[string]any, path P, value any) error {
+	segments := pathSegments(path)
+	if len(segments) == 0 {
+		return pathError(segments, ErrPathNotFound)
+	}
+
+	var cur any = arr
+
+	for i, segment := range segments {
+		last := i == len(segments)-1
+
+		switch node := cur.(type) {
+		case map[string]any:
+			if last {
+				node[segment] = value
+
+				return nil
+			}
+
+			next, ok := node[segment]
+			if !ok || next == nil {
+				next = make(map[string]any)
+				node[segment] = next
+			}
+
+			cur = next
+		case []any:
+			idx, ok := sliceIndex(node, segment)
+			if !ok {
+				return pathError(segments[:i+1], ErrPathNotFound)
+			}
+
+			if last {
+				node[idx] = value
+
+				return nil
+			}
+
+			if node[idx] == nil {
+				node[idx] = make(map[string]any)
+			}
+
+			cur = node[idx]
+		default:
+			return pathError(segments[:i], fmt.Errorf("%w: %T is not a container", ErrPathType, node))
+		}
+	}
+
+	return nil
+}
+
+// MapDeletePath removes the value stored on the path in a nested map.
+// Elements removed from []any shift the following elements to the left.
+// Returns ErrPathNotFound if the path is missing.
+func MapDeletePath[P Path](arr map[string]any, path P) error {
+	segments := pathSegments(path)
+	if len(segments) == 0 {
+		return pathError(segments, ErrPathNotFound)
+	}
+
+	_, err := deletePath(arr, segments, 0)
+
+	return err
+}
+
+func deletePath(cur any, segments []string, i int) (any, error) {
+	segment := segments[i]
+	last := i == len(segments)-1
+
+	switch node := cur.(type) {
+	case map[string]any:
+		next, ok := node[segment]
+		if !ok {
+			return nil, pathError(segments[:i+1], ErrPathNotFound)
+		}
+
+		if last {
+			delete(node, segment)
+
+			return node, nil
+		}
+
+		updated, err := deletePath(next, segments, i+1)
+		if err != nil {
+			return nil, err
+		}
+
+		node[segment] = updated
+
+		return node, nil
+	case []any:
+		idx, ok := sliceIndex(node, segment)
+		if !ok {
+			return nil, pathError(segments[:i+1], ErrPathNotFound)
+		}
+
+		if last {
+			return append(node[:idx], node[idx+1:]...), nil
+		}
+
+		updated, err := deletePath(node[idx], segments, i+1)
+		if err != nil {
+			return nil, err
+		}
+
+		node[idx] = updated
+
+		return node, nil
+	default:
+		return nil, pathError(segments[:i+1], ErrPathNotFound)
+	}
+}
+
+// MapFlatten creates a new single-level map from a nested map with keys joined by PathSeparator,
+// e.g. {"a": {"b": 1}} becomes {"a.b": 1}.
+// Only map[string]any values are flattened, slices and empty maps are kept as values.
+func MapFlatten(arr map[string]any) map[string]any {
+	r := make(map[string]any)
+	flattenInto(r, "", arr)
+
+	return r
+}
+
+func flattenInto(dst map[string]any, prefix string, arr map[string]any) {
+	for k, v := range arr {
+		key := k
+		if prefix != "" {
+			key = prefix + PathSeparator + k
+		}
+
+		if nested, ok := v.(map[string]any); ok && len(nested) > 0 {
+			flattenInto(dst, key, nested)
+
+			continue
+		}
+
+		dst[key] = v
+	}
+}
+
+// MapUnflatten creates a new nested map from a single-level map with keys joined by PathSeparator,
+// e.g. {"a.b": 1} becomes {"a": {"b": 1}}.
+// Returns ErrPathType if keys conflict, e.g. both "a" and "a.b" are present.
+func MapUnflatten(arr map[string]any) (map[string]any, error) {
+	r := make(map[string]any)
+
+	keys := MapKeys(arr)
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		segments := strings.Split(key, PathSeparator)
+		node := r
+
+		for i, segment := range segments[:len(segments)-1] {
+			next, ok := node[segment]
+			if !ok {
+				next = make(map[string]any)
+				node[segment] = next
+			}
+
+			nested, ok := next.(map[string]any)
+			if !ok {
+				return nil, pathError(segments[:i+1], fmt.Errorf("%w: %T is not a map", ErrPathType, next))
+			}
+
+			node = nested
+		}
+
+		leaf := segments[len(segments)-1]
+		if _, ok := node[leaf]; ok {
+			return nil, pathError(segments, fmt.Errorf("%w: conflicting keys", ErrPathType))
+		}
+
+		node[leaf] = deepCopyValue(arr[key])
+	}
+
+	return r, nil
+}
@@ -0,0 +1,333 @@
+package arrays_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+func testDocument() map[string]any {
+	return map[string]any{
+		"name": "service",
+		"db": map[string]any{
+			"host": "localhost",
+			"port": 5432,
+		},
+		"servers": []any{
+			map[string]any{"addr": "10.0.0.1"},
+			map[string]any{"addr": "10.0.0.2"},
+		},
+		"dotted.key": true,
+	}
+}
+
+func TestMapGetPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		path      string
+		want      any
+		wantError error
+	}{
+		{
+			name: "top level key",
+			path: "name",
+			want: "service",
+		},
+		{
+			name: "nested key",
+			path: "db.port",
+			want: 5432,
+		},
+		{
+			name: "index into slice",
+			path: "servers.1.addr",
+			want: "10.0.0.2",
+		},
+		{
+			name:      "missing key",
+			path:      "db.user",
+			wantError: arrays.ErrPathNotFound,
+		},
+		{
+			name:      "index out of range",
+			path:      "servers.2.addr",
+			wantError: arrays.ErrPathNotFound,
+		},
+		{
+			name:      "non-numeric index",
+			path:      "servers.first",
+			wantError: arrays.ErrPathNotFound,
+		},
+		{
+			name:      "path through scalar",
+			path:      "name.first",
+			wantError: arrays.ErrPathNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := arrays.MapGetPath(testDocument(), tt.path)
+
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("MapGetPath() error = %v, wantError %v", err, tt.wantError)
+			}
+
+			if tt.wantError == nil && got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapGetPathSegments(t *testing.T) {
+	t.Parallel()
+
+	got, err := arrays.MapGetPath(testDocument(), []string{"dotted.key"})
+	if err != nil {
+		t.Fatalf("MapGetPath() error = %v", err)
+	}
+
+	if got != true {
+		t.Errorf("got %v, want true", got)
+	}
+
+	root, err := arrays.MapGetPath(testDocument(), []string{})
+	if err != nil {
+		t.Fatalf("MapGetPath() error = %v", err)
+	}
+
+	if _, ok := root.(map[string]any); !ok {
+		t.Errorf("got %T, want map[string]any", root)
+	}
+}
+
+func TestMapGetPathAs(t *testing.T) {
+	t.Parallel()
+
+	port, err := arrays.MapGetPathAs[int](testDocument(), "db.port")
+	if err != nil || port != 5432 {
+		t.Errorf("got %v, %v, want 5432", port, err)
+	}
+
+	_, err = arrays.MapGetPathAs[string](testDocument(), "db.port")
+	if !errors.Is(err, arrays.ErrPathType) {
+		t.Errorf("got error %v, want %v", err, arrays.ErrPathType)
+	}
+
+	_, err = arrays.MapGetPathAs[string](testDocument(), []string{"db", "user"})
+	if !errors.Is(err, arrays.ErrPathNotFound) {
+		t.Errorf("got error %v, want %v", err, arrays.ErrPathNotFound)
+	}
+}
+
+func TestMapSetPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		path      string
+		value     any
+		wantError error
+	}{
+		{
+			name:  "overwrite nested key",
+			path:  "db.port",
+			value: 6432,
+		},
+		{
+			name:  "create intermediate maps",
+			path:  "cache.redis.host",
+			value: "redis",
+		},
+		{
+			name:  "set inside slice",
+			path:  "servers.0.addr",
+			value: "10.0.0.9",
+		},
+		{
+			name:  "replace slice element",
+			path:  "servers.1",
+			value: "gone",
+		},
+		{
+			name:      "index out of range",
+			path:      "servers.5.addr",
+			value:     "x",
+			wantError: arrays.ErrPathNotFound,
+		},
+		{
+			name:      "path through scalar",
+			path:      "name.first",
+			value:     "x",
+			wantError: arrays.ErrPathType,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc := testDocument()
+			err := arrays.MapSetPath(doc, tt.path, tt.value)
+
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("MapSetPath() error = %v, wantError %v", err, tt.wantError)
+			}
+
+			if tt.wantError != nil {
+				return
+			}
+
+			got, err := arrays.MapGetPath(doc, tt.path)
+			if err != nil {
+				t.Fatalf("MapGetPath() error = %v", err)
+			}
+
+			if got != tt.value {
+				t.Errorf("got %v, want %v", got, tt.value)
+			}
+		})
+	}
+}
+
+func TestMapDeletePath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		path      string
+		check     func(doc map[string]any) bool
+		wantError error
+	}{
+		{
+			name: "delete nested key",
+			path: "db.port",
+			check: func(doc map[string]any) bool {
+				db := doc["db"].(map[string]any)
+				_, ok := db["port"]
+				return !ok && len(db) == 1
+			},
+		},
+		{
+			name: "delete slice element",
+			path: "servers.0",
+			check: func(doc map[string]any) bool {
+				servers := doc["servers"].([]any)
+				return len(servers) == 1 && servers[0].(map[string]any)["addr"] == "10.0.0.2"
+			},
+		},
+		{
+			name: "delete key inside slice element",
+			path: "servers.1.addr",
+			check: func(doc map[string]any) bool {
+				return len(doc["servers"].([]any)[1].(map[string]any)) == 0
+			},
+		},
+		{
+			name:      "missing key",
+			path:      "db.user",
+			wantError: arrays.ErrPathNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc := testDocument()
+			err := arrays.MapDeletePath(doc, tt.path)
+
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("MapDeletePath() error = %v, wantError %v", err, tt.wantError)
+			}
+
+			if tt.wantError == nil && !tt.check(doc) {
+				t.Errorf("unexpected document after delete: %v", doc)
+			}
+		})
+	}
+}
+
+func TestMapFlatten(t *testing.T) {
+	t.Parallel()
+
+	arr := map[string]any{
+		"a": map[string]any{
+			"b": map[string]any{"c": 1},
+			"d": []any{1, 2},
+		},
+		"e":     "x",
+		"empty": map[string]any{},
+	}
+
+	want := map[string]any{
+		"a.b.c": 1,
+		"a.d":   []any{1, 2},
+		"e":     "x",
+		"empty": map[string]any{},
+	}
+
+	got := arrays.MapFlatten(arr)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	back, err := arrays.MapUnflatten(got)
+	if err != nil {
+		t.Fatalf("MapUnflatten() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(back, arr) {
+		t.Errorf("got %v, want %v", back, arr)
+	}
+}
+
+func TestMapUnflatten(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		arr       map[string]any
+		want      map[string]any
+		wantError error
+	}{
+		{
+			name: "nest dotted keys",
+			arr:  map[string]any{"a.b": 1, "a.c": 2, "d": 3},
+			want: map[string]any{"a": map[string]any{"b": 1, "c": 2}, "d": 3},
+		},
+		{
+			name:      "scalar and nested key conflict",
+			arr:       map[string]any{"a": 1, "a.b": 2},
+			wantError: arrays.ErrPathType,
+		},
+		{
+			name: "empty map",
+			arr:  map[string]any{},
+			want: map[string]any{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := arrays.MapUnflatten(tt.arr)
+
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("MapUnflatten() error = %v, wantError %v", err, tt.wantError)
+			}
+
+			if tt.wantError == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package arrays
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Sleeper pauses the current goroutine for the duration or until the context is done.
+type Sleeper func(ctx context.Context, d time.Duration) error
+
+// RetryPolicy configures retries of failed callbacks.
+// The delay before the n-th retry is InitialBackoff*Multiplier^(n-1), capped by MaxBackoff if it's positive,
+// and reduced by a random fraction of up to Jitter, e.g. 0.2 makes every delay 80-100% of the computed one.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of calls per element, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries if it's positive.
+	MaxBackoff time.Duration
+	// Multiplier grows the delay after every retry, 2 if it's not greater than 1.
+	Multiplier float64
+	// Jitter is a fraction of the delay in [0, 1] that is randomly subtracted from it.
+	Jitter float64
+	// Retryable reports whether an error should be retried, all errors are retried if it's nil.
+	Retryable func(err error) bool
+}
+
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier <= 1 {
+		multiplier = 2
+	}
+
+	d := float64(p.InitialBackoff)
+	for i := 1; i < retry; i++ {
+		d *= multiplier
+
+		if p.MaxBackoff > 0 && d >= float64(p.MaxBackoff) {
+			break
+		}
+
+		// Stop growing before d reaches +Inf, which turns into NaN once jitter is subtracted.
+		if d >= math.MaxInt64 {
+			d = math.MaxInt64
+
+			break
+		}
+	}
+
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		d -= d * p.Jitter * rand.Float64()
+	}
+
+	return floatDuration(d)
+}
+
+// floatDuration converts nanoseconds to a duration, saturating at the maximum duration
+// instead of overflowing into negative values. NaN is treated as saturated too.
+func floatDuration(d float64) time.Duration {
+	if d >= math.MaxInt64 || math.IsNaN(d) {
+		return math.MaxInt64
+	}
+
+	return time.Duration(d)
+}
+
+type processConfig struct {
+	parallelism int
+	ctx         context.Context
+	clock       Clock
+	sleep       Sleeper
+	retry       RetryPolicy
+	rate        float64
+	limiter     *rateLimiter
+	hooks       Hooks
+}
+
+// ProcessOption configures batch and parallel operations.
+type ProcessOption func(*processConfig)
+
+// WithParallelism sets the number of goroutines used by parallel operations, runtime.GOMAXPROCS by default.
+// Values less than 1 are ignored.
+func WithParallelism(n int) ProcessOption {
+	return func(c *processConfig) {
+		if n > 0 {
+			c.parallelism = n
+		}
+	}
+}
+
+// WithContext sets the context that stops processing of the remaining elements when it's done.
+func WithContext(ctx context.Context) ProcessOption {
+	return func(c *processConfig) {
+		c.ctx = ctx
+	}
+}
+
+// WithProcessClock sets the clock used for rate limiting and the default sleeper, SystemClock by default.
+func WithProcessClock(clock Clock) ProcessOption {
+	return func(c *processConfig) {
+		c.clock = clock
+	}
+}
+
+// WithSleeper sets the function used to wait between retries and for the rate limit.
+// By default it waits for a timer of the configured clock.
+func WithSleeper(sleep Sleeper) ProcessOption {
+	return func(c *processConfig) {
+		c.sleep = sleep
+	}
+}
+
+// WithRetry retries failed callbacks for every element according to the policy.
+func WithRetry(policy RetryPolicy) ProcessOption {
+	return func(c *processConfig) {
+		c.retry = policy
+	}
+}
+
+// WithRateLimit limits the number of callback calls, including retries, to opsPerSecond
+// across all goroutines of an operation. Values that are not positive disable the limit.
+func WithRateLimit(opsPerSecond float64) ProcessOption {
+	return func(c *processConfig) {
+		c.rate = opsPerSecond
+	}
+}
+
+func newProcessConfig(opts []ProcessOption) *processConfig {
+	c := &processConfig{
+		parallelism: runtime.GOMAXPROCS(0),
+		ctx:         context.Background(),
+		clock:       SystemClock,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.sleep == nil {
+		c.sleep = clockSleeper(c.clock)
+	}
+
+	if c.rate > 0 {
+		c.limiter = &rateLimiter{interval: floatDuration(float64(time.Second) / c.rate)}
+	}
+
+	return c
+}
+
+func clockSleeper(clock Clock) Sleeper {
+	return func(ctx context.Context, d time.Duration) error {
+		if d <= 0 {
+			return ctx.Err()
+		}
+
+		t := clock.NewTimer(d)
+		defer t.Stop()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C():
+			return nil
+		}
+	}
+}
+
+// rateLimiter spaces operations at least interval apart.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// reserve books the next free slot and returns how long to wait for it.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.next.Before(now) {
+		l.next = now
+	}
+
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+
+	return wait
+}
+
+// processCall calls the callback for a single element honoring retries, rate limit and context of the config.
+// Callback errors are wrapped with the "callback" prefix, context errors are returned as is.
+func processCall[I, T any](c *processConfig, v I, callback func(value I) (T, error)) (T, error) {
+	for attempt := 1; ; attempt++ {
+		if err := c.ctx.Err(); err != nil {
+			return *new(T), err
+		}
+
+		if c.limiter != nil {
+			if err := c.sleep(c.ctx, c.limiter.reserve(c.clock.Now())); err != nil {
+				return *new(T), err
+			}
+		}
+
+		res, err := callback(v)
+		if err == nil {
+			return res, nil
+		}
+
+		if attempt >= c.retry.MaxAttempts || (c.retry.Retryable != nil && !c.retry.Retryable(err)) {
+			return *new(T), fmt.Errorf("callback: %w", err)
+		}
+
+		if err := c.sleep(c.ctx, c.retry.backoff(attempt)); err != nil {
+			return *new(T), err
+		}
+	}
+}
@@ -0,0 +1,367 @@
+package arrays_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+var errTransient = errors.New("transient")
+
+// recordingSleeper advances the fake clock instead of sleeping and records requested delays.
+type recordingSleeper struct {
+	mu     sync.Mutex
+	clock  *fakeClock
+	delays []time.Duration
+}
+
+func (s *recordingSleeper) Sleep(ctx context.Context, d time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.delays = append(s.delays, d)
+	s.clock.Advance(d)
+
+	return ctx.Err()
+}
+
+func (s *recordingSleeper) Delays() []time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]time.Duration(nil), s.delays...)
+}
+
+// flaky returns a callback that fails the given number of times for every value before succeeding.
+func flaky(failures int, err error) func(int) (int, error) {
+	var mu sync.Mutex
+	calls := make(map[int]int)
+
+	return func(v int) (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		calls[v]++
+		if calls[v] <= failures {
+			return 0, err
+		}
+
+		return v * 10, nil
+	}
+}
+
+func TestArrayProcessErrRetry(t *testing.T) {
+	t.Parallel()
+
+	policy := arrays.RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     150 * time.Millisecond,
+	}
+
+	tests := []struct {
+		name       string
+		callback   func(int) (int, error)
+		policy     arrays.RetryPolicy
+		want       []int
+		wantDelays []time.Duration
+		wantError  error
+	}{
+		{
+			name:       "succeeds after retries",
+			callback:   flaky(2, errTransient),
+			policy:     policy,
+			want:       []int{10},
+			wantDelays: []time.Duration{100 * time.Millisecond, 150 * time.Millisecond},
+		},
+		{
+			name:       "attempts exhausted",
+			callback:   flaky(3, errTransient),
+			policy:     policy,
+			wantDelays: []time.Duration{100 * time.Millisecond, 150 * time.Millisecond},
+			wantError:  errTransient,
+		},
+		{
+			name:     "error is not retryable",
+			callback: flaky(1, errTransient),
+			policy: arrays.RetryPolicy{
+				MaxAttempts: 3,
+				Retryable: func(err error) bool {
+					return !errors.Is(err, errTransient)
+				},
+			},
+			wantDelays: []time.Duration{},
+			wantError:  errTransient,
+		},
+		{
+			name:       "no retries by default",
+			callback:   flaky(1, errTransient),
+			wantDelays: []time.Duration{},
+			wantError:  errTransient,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sleeper := &recordingSleeper{clock: newFakeClock()}
+
+			got, err := arrays.ArrayProcessErr([]int{1}, tt.callback,
+				arrays.WithRetry(tt.policy),
+				arrays.WithSleeper(sleeper.Sleep),
+			)
+
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("ArrayProcessErr() error = %v, wantError %v", err, tt.wantError)
+			}
+
+			if tt.wantError == nil && (len(got) != len(tt.want) || got[0] != tt.want[0]) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+
+			delays := sleeper.Delays()
+			if len(delays) != len(tt.wantDelays) {
+				t.Fatalf("got delays %v, want %v", delays, tt.wantDelays)
+			}
+
+			for i, d := range tt.wantDelays {
+				if delays[i] != d {
+					t.Errorf("got delay %v, want %v", delays[i], d)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryJitter(t *testing.T) {
+	t.Parallel()
+
+	sleeper := &recordingSleeper{clock: newFakeClock()}
+
+	_, err := arrays.ArrayProcessErr([]int{1}, flaky(5, errTransient),
+		arrays.WithRetry(arrays.RetryPolicy{
+			MaxAttempts:    5,
+			InitialBackoff: time.Second,
+			Multiplier:     3,
+			Jitter:         0.5,
+		}),
+		arrays.WithSleeper(sleeper.Sleep),
+	)
+	if !errors.Is(err, errTransient) {
+		t.Fatalf("ArrayProcessErr() error = %v, want %v", err, errTransient)
+	}
+
+	base := time.Second
+	for _, d := range sleeper.Delays() {
+		if d > base || d < base/2 {
+			t.Errorf("got delay %v, want within [%v, %v]", d, base/2, base)
+		}
+
+		base *= 3
+	}
+}
+
+func TestRetryBackoffOverflow(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		policy   arrays.RetryPolicy
+		minDelay time.Duration
+	}{
+		{
+			name: "without jitter",
+			policy: arrays.RetryPolicy{
+				MaxAttempts:    40,
+				InitialBackoff: time.Second,
+			},
+			minDelay: math.MaxInt64,
+		},
+		{
+			name: "with jitter",
+			policy: arrays.RetryPolicy{
+				MaxAttempts:    4,
+				InitialBackoff: time.Second,
+				Multiplier:     1e300,
+				Jitter:         0.5,
+			},
+			minDelay: math.MaxInt64 / 2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sleeper := &recordingSleeper{clock: newFakeClock()}
+
+			_, err := arrays.ArrayProcessErr([]int{1}, flaky(tt.policy.MaxAttempts, errTransient),
+				arrays.WithRetry(tt.policy),
+				arrays.WithSleeper(sleeper.Sleep),
+			)
+			if !errors.Is(err, errTransient) {
+				t.Fatalf("ArrayProcessErr() error = %v, want %v", err, errTransient)
+			}
+
+			delays := sleeper.Delays()
+			if len(delays) != tt.policy.MaxAttempts-1 {
+				t.Fatalf("got %d delays, want %d", len(delays), tt.policy.MaxAttempts-1)
+			}
+
+			for i, d := range delays {
+				if d <= 0 {
+					t.Errorf("delay %d is %v, want positive", i, d)
+				}
+
+				if tt.policy.Jitter == 0 && i > 0 && d < delays[i-1] {
+					t.Errorf("delay %d is %v, want at least %v", i, d, delays[i-1])
+				}
+			}
+
+			if last := delays[len(delays)-1]; last < tt.minDelay {
+				t.Errorf("last delay is %v, want at least %v", last, tt.minDelay)
+			}
+		})
+	}
+}
+
+func TestArrayProcessErrRateLimitOverflow(t *testing.T) {
+	t.Parallel()
+
+	sleeper := &recordingSleeper{clock: newFakeClock()}
+
+	_, err := arrays.ArrayProcessErr([]int{1, 2}, flaky(0, nil),
+		arrays.WithRateLimit(1e-12),
+		arrays.WithSleeper(sleeper.Sleep),
+	)
+	if err != nil {
+		t.Fatalf("ArrayProcessErr() error = %v", err)
+	}
+
+	// A rate this small means the second call practically never happens, it must not run right away.
+	if delays := sleeper.Delays(); len(delays) != 2 || delays[1] < 1000*time.Hour {
+		t.Errorf("got delays %v, want the second one to be at least 1000h", delays)
+	}
+}
+
+func TestArrayProcessErrRateLimit(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock()
+	sleeper := &recordingSleeper{clock: clock}
+	start := clock.Now()
+
+	var calls []time.Duration
+
+	_, err := arrays.ArrayProcessErr([]int{1, 2, 3, 4, 5}, func(v int) (int, error) {
+		calls = append(calls, clock.Now().Sub(start))
+		return v, nil
+	},
+		arrays.WithRateLimit(10),
+		arrays.WithProcessClock(clock),
+		arrays.WithSleeper(sleeper.Sleep),
+	)
+	if err != nil {
+		t.Fatalf("ArrayProcessErr() error = %v", err)
+	}
+
+	for i, at := range calls {
+		if want := time.Duration(i) * 100 * time.Millisecond; at != want {
+			t.Errorf("call %d at %v, want %v", i, at, want)
+		}
+	}
+}
+
+func TestArrayProcessErrContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+
+	_, err := arrays.ArrayProcessErr([]int{1, 2, 3}, func(v int) (int, error) {
+		calls++
+		cancel()
+		return v, nil
+	}, arrays.WithContext(ctx))
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+
+	if calls != 1 {
+		t.Errorf("got %d calls, want 1", calls)
+	}
+}
+
+func TestArrayParallelProcessErr(t *testing.T) {
+	t.Parallel()
+
+	arr := make([]int, 100)
+	for i := range arr {
+		arr[i] = i
+	}
+
+	sleeper := &recordingSleeper{clock: newFakeClock()}
+
+	got, err := arrays.ArrayParallelProcessErr(arr, flaky(1, errTransient),
+		arrays.WithParallelism(8),
+		arrays.WithRetry(arrays.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}),
+		arrays.WithSleeper(sleeper.Sleep),
+	)
+	if err != nil {
+		t.Fatalf("ArrayParallelProcessErr() error = %v", err)
+	}
+
+	if len(got) != len(arr) {
+		t.Fatalf("got length %d, want %d", len(got), len(arr))
+	}
+
+	for i, v := range arr {
+		if got[i] != v*10 {
+			t.Errorf("got %v, want %v", got[i], v*10)
+		}
+	}
+
+	if len(sleeper.Delays()) != len(arr) {
+		t.Errorf("got %d retries, want %d", len(sleeper.Delays()), len(arr))
+	}
+}
+
+func TestArrayParallelProcessErrFailure(t *testing.T) {
+	t.Parallel()
+
+	arr := make([]int, 1000)
+	for i := range arr {
+		arr[i] = i
+	}
+
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+
+	_, err := arrays.ArrayParallelProcessErr(arr, func(v int) (int, error) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+
+		if v == 10 {
+			return 0, errTransient
+		}
+		return v, nil
+	}, arrays.WithParallelism(4))
+
+	if !errors.Is(err, errTransient) {
+		t.Fatalf("got error %v, want %v", err, errTransient)
+	}
+
+	if calls == len(arr) {
+		t.Errorf("remaining elements were processed after failure")
+	}
+}
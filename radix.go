@@ -0,0 +1,210 @@
+package arrays
+
+import (
+	"reflect"
+	"sort"
+)
+
+const (
+	// radixThreshold is the array length below which a comparison sort is faster than a radix sort.
+	radixThreshold = 64
+	// msdInsertionThreshold is the bucket length below which string buckets are finished by insertion sort.
+	msdInsertionThreshold = 32
+)
+
+// ArrayRadixSortBy returns a new array sorted in ascending order by the key of each element.
+// Integer keys are sorted by an LSD radix sort, string keys by an MSD radix sort
+// comparing bytes. The sort is stable and the key function is called once per element.
+// Runs in O(n·w) where w is the key width in bytes.
+func ArrayRadixSortBy[I any, K RadixKey](arr []I, key func(value I) K) []I {
+	keys := make([]K, len(arr))
+	for i, v := range arr {
+		keys[i] = key(v)
+	}
+
+	if len(arr) < radixThreshold {
+		order := make([]int, len(arr))
+		for i := range order {
+			order[i] = i
+		}
+
+		sort.SliceStable(order, func(i, j int) bool {
+			return keys[order[i]] < keys[order[j]]
+		})
+
+		return arrayPermute(arr, order)
+	}
+
+	var order []int
+
+	// K may be a named type, reflect gives access to its underlying value without unsafe conversions.
+	values := reflect.ValueOf(keys)
+
+	switch values.Type().Elem().Kind() {
+	case reflect.String:
+		strs := make([]string, len(keys))
+		for i := range strs {
+			strs[i] = values.Index(i).String()
+		}
+
+		order = msdRadixSort(strs)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		ints := make([]uint64, len(keys))
+		for i := range ints {
+			// Flipping the sign bit maps signed order onto unsigned order.
+			ints[i] = uint64(values.Index(i).Int()) ^ (1 << 63)
+		}
+
+		order = lsdRadixSort(ints)
+	default:
+		ints := make([]uint64, len(keys))
+		for i := range ints {
+			ints[i] = values.Index(i).Uint()
+		}
+
+		order = lsdRadixSort(ints)
+	}
+
+	return arrayPermute(arr, order)
+}
+
+// arrayPermute creates a new array with res[i] = arr[order[i]].
+func arrayPermute[I any](arr []I, order []int) []I {
+	res := make([]I, len(order))
+	for i, j := range order {
+		res[i] = arr[j]
+	}
+
+	return res
+}
+
+// lsdRadixSort returns the indexes of the keys in stable ascending order.
+// Bytes that are equal across all keys are skipped.
+func lsdRadixSort(keys []uint64) []int {
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+
+	var diff uint64
+	for _, k := range keys {
+		diff |= k ^ keys[0]
+	}
+
+	src, dst := keys, make([]uint64, len(keys))
+	srcOrder, dstOrder := order, make([]int, len(keys))
+
+	for shift := uint(0); shift < 64; shift += 8 {
+		if (diff>>shift)&0xff == 0 {
+			continue
+		}
+
+		var count [256]int
+		for _, k := range src {
+			count[(k>>shift)&0xff]++
+		}
+
+		offset := 0
+		for b, c := range count {
+			count[b] = offset
+			offset += c
+		}
+
+		for i, k := range src {
+			b := (k >> shift) & 0xff
+			dst[count[b]] = k
+			dstOrder[count[b]] = srcOrder[i]
+			count[b]++
+		}
+
+		src, dst = dst, src
+		srcOrder, dstOrder = dstOrder, srcOrder
+	}
+
+	return srcOrder
+}
+
+// msdRadixSort returns the indexes of the keys in stable ascending order.
+func msdRadixSort(keys []string) []int {
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+
+	msdRadixSortRange(keys, order, make([]int, len(keys)), 0)
+
+	return order
+}
+
+// msdRadixSortRange sorts the indexes by the bytes of the keys starting at depth,
+// all keys in the range share the bytes before depth. buf has the length of order.
+func msdRadixSortRange(keys []string, order, buf []int, depth int) {
+	for {
+		if len(order) < msdInsertionThreshold {
+			insertionSortStrings(keys, order, depth)
+
+			return
+		}
+
+		// Bucket 0 holds keys that end at depth, they sort before any longer key.
+		var count [257]int
+		for _, i := range order {
+			count[byteAt(keys[i], depth)]++
+		}
+
+		if count[0] == len(order) {
+			return
+		}
+
+		if count[byteAt(keys[order[0]], depth)] == len(order) {
+			// A common byte, nothing to distribute.
+			depth++
+
+			continue
+		}
+
+		var start [257]int
+
+		offset := 0
+		for b, c := range count {
+			start[b] = offset
+			offset += c
+		}
+
+		next := start
+		for _, i := range order {
+			b := byteAt(keys[i], depth)
+			buf[next[b]] = i
+			next[b]++
+		}
+
+		copy(order, buf)
+
+		for b := 1; b < len(count); b++ {
+			if count[b] > 1 {
+				from, to := start[b], start[b]+count[b]
+				msdRadixSortRange(keys, order[from:to], buf[from:to], depth+1)
+			}
+		}
+
+		return
+	}
+}
+
+// byteAt returns the byte of the key at the index plus one, or zero if the key is shorter.
+func byteAt(key string, i int) int {
+	if i < len(key) {
+		return int(key[i]) + 1
+	}
+
+	return 0
+}
+
+// insertionSortStrings stably sorts the indexes by the keys, comparing from depth.
+func insertionSortStrings(keys []string, order []int, depth int) {
+	for i := 1; i < len(order); i++ {
+		for j := i; j > 0 && keys[order[j-1]][depth:] > keys[order[j]][depth:]; j-- {
+			order[j-1], order[j] = order[j], order[j-1]
+		}
+	}
+}
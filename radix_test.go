@@ -0,0 +1,229 @@
+package arrays_test
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+type userID int64
+
+type user struct {
+	ID   userID
+	Name string
+	Seq  int
+}
+
+func randomUsers(n int, idRange int64) []user {
+	rnd := rand.New(rand.NewSource(7))
+	users := make([]user, n)
+
+	for i := range users {
+		id := rnd.Int63n(idRange) - idRange/2
+		users[i] = user{
+			ID:   userID(id),
+			Name: strconv.FormatInt(id, 36)[:1+rnd.Intn(3)],
+			Seq:  i,
+		}
+	}
+
+	return users
+}
+
+func stableSorted[I any](arr []I, less func(a, b I) bool) []I {
+	res := make([]I, len(arr))
+	copy(res, arr)
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return less(res[i], res[j])
+	})
+
+	return res
+}
+
+func TestArrayRadixSortBy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		n    int
+	}{
+		{name: "empty", n: 0},
+		{name: "short", n: 20},
+		{name: "long", n: 5000},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			users := randomUsers(tt.n, 1<<40)
+			original := make([]user, len(users))
+			copy(original, users)
+
+			byID := arrays.ArrayRadixSortBy(users, func(u user) userID { return u.ID })
+			wantByID := stableSorted(users, func(a, b user) bool { return a.ID < b.ID })
+
+			if !reflect.DeepEqual(byID, wantByID) {
+				t.Errorf("ArrayRadixSortBy() by ID differs from sort.SliceStable")
+			}
+
+			byName := arrays.ArrayRadixSortBy(users, func(u user) string { return u.Name })
+			wantByName := stableSorted(users, func(a, b user) bool { return a.Name < b.Name })
+
+			if !reflect.DeepEqual(byName, wantByName) {
+				t.Errorf("ArrayRadixSortBy() by name differs from sort.SliceStable")
+			}
+
+			if !reflect.DeepEqual(users, original) {
+				t.Errorf("ArrayRadixSortBy() modified the input")
+			}
+		})
+	}
+}
+
+func TestArrayRadixSortByKeyTypes(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(11))
+	ints := make([]int64, 1000)
+
+	for i := range ints {
+		ints[i] = rnd.Int63() - rnd.Int63()
+	}
+
+	ints[0], ints[1] = -1<<63, 1<<63-1
+
+	tests := []struct {
+		name string
+		got  func() []int64
+		less func(a, b int64) bool
+	}{
+		{
+			name: "int8",
+			got: func() []int64 {
+				return arrays.ArrayRadixSortBy(ints, func(v int64) int8 { return int8(v) })
+			},
+			less: func(a, b int64) bool { return int8(a) < int8(b) },
+		},
+		{
+			name: "int64",
+			got: func() []int64 {
+				return arrays.ArrayRadixSortBy(ints, func(v int64) int64 { return v })
+			},
+			less: func(a, b int64) bool { return a < b },
+		},
+		{
+			name: "uint16",
+			got: func() []int64 {
+				return arrays.ArrayRadixSortBy(ints, func(v int64) uint16 { return uint16(v) })
+			},
+			less: func(a, b int64) bool { return uint16(a) < uint16(b) },
+		},
+		{
+			name: "uint64",
+			got: func() []int64 {
+				return arrays.ArrayRadixSortBy(ints, func(v int64) uint64 { return uint64(v) })
+			},
+			less: func(a, b int64) bool { return uint64(a) < uint64(b) },
+		},
+		{
+			name: "constant",
+			got: func() []int64 {
+				return arrays.ArrayRadixSortBy(ints, func(v int64) int { return 42 })
+			},
+			less: func(a, b int64) bool { return false },
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got, want := tt.got(), stableSorted(ints, tt.less); !reflect.DeepEqual(got, want) {
+				t.Errorf("ArrayRadixSortBy() differs from sort.SliceStable")
+			}
+		})
+	}
+}
+
+func TestArrayRadixSortByStrings(t *testing.T) {
+	t.Parallel()
+
+	words := make([]string, 0, 500)
+	for i := 0; i < 100; i++ {
+		words = append(words, "", "a", "ab", "prefix/common/"+strconv.Itoa(i%7), "\xff"+strconv.Itoa(i))
+	}
+
+	got := arrays.ArrayRadixSortBy(words, func(s string) string { return s })
+	want := stableSorted(words, func(a, b string) bool { return a < b })
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ArrayRadixSortBy() = %v, want %v", got, want)
+	}
+}
+
+func TestArrayRadixSortByKeyCalls(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{8, 1000} {
+		users := randomUsers(n, 1<<40)
+		calls := 0
+
+		arrays.ArrayRadixSortBy(users, func(u user) userID {
+			calls++
+
+			return u.ID
+		})
+
+		if calls != n {
+			t.Errorf("key called %d times for %d elements, want %d", calls, n, n)
+		}
+	}
+}
+
+func BenchmarkArrayRadixSortByInt64(b *testing.B) {
+	users := randomUsers(1_000_000, 1<<62)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		arrays.ArrayRadixSortBy(users, func(u user) userID { return u.ID })
+	}
+}
+
+func BenchmarkSliceStableInt64(b *testing.B) {
+	users := randomUsers(1_000_000, 1<<62)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		res := append([]user(nil), users...)
+		sort.SliceStable(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	}
+}
+
+func BenchmarkArrayRadixSortByString(b *testing.B) {
+	users := randomUsers(1_000_000, 1<<62)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		arrays.ArrayRadixSortBy(users, func(u user) string { return u.Name })
+	}
+}
+
+func BenchmarkSliceStableString(b *testing.B) {
+	users := randomUsers(1_000_000, 1<<62)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		res := append([]user(nil), users...)
+		sort.SliceStable(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	}
+}
@@ -0,0 +1,355 @@
+package arrays
+
+import (
+	"hash/maphash"
+	"math"
+	"reflect"
+	"sync"
+)
+
+const defaultShards = 32
+
+// ShardedMap is a map safe for concurrent use, split into shards guarded by separate locks.
+// Operations on different shards don't block each other.
+// The zero value is not usable, create maps with NewShardedMap.
+type ShardedMap[I comparable, K any] struct {
+	shards []*mapShard[I, K]
+	hash   func(key I) uint64
+}
+
+type mapShard[I comparable, K any] struct {
+	mu sync.RWMutex
+	m  map[I]K
+}
+
+// NewShardedMap creates a new ShardedMap with the provided number of shards, 32 if it's less than 1.
+// Keys are distributed across shards by hash, if it's nil a default hash function is used,
+// which hashes keys by their kind, so named types like `type UserID int64` take the same fast path.
+func NewShardedMap[I comparable, K any](shards int, hash func(key I) uint64) *ShardedMap[I, K] {
+	if shards < 1 {
+		shards = defaultShards
+	}
+
+	if hash == nil {
+		hash = defaultHash[I](maphash.MakeSeed())
+	}
+
+	m := &ShardedMap[I, K]{
+		shards: make([]*mapShard[I, K], shards),
+		hash:   hash,
+	}
+
+	for i := range m.shards {
+		m.shards[i] = &mapShard[I, K]{m: make(map[I]K)}
+	}
+
+	return m
+}
+
+func defaultHash[I comparable](seed maphash.Seed) func(key I) uint64 {
+	return func(key I) uint64 {
+		// Built-in types take the fast path, reflect would make every key escape to the heap.
+		switch k := any(key).(type) {
+		case string:
+			return hashString(seed, k)
+		case int:
+			return mixHash(uint64(k))
+		case int8:
+			return mixHash(uint64(k))
+		case int16:
+			return mixHash(uint64(k))
+		case int32:
+			return mixHash(uint64(k))
+		case int64:
+			return mixHash(uint64(k))
+		case uint:
+			return mixHash(uint64(k))
+		case uint8:
+			return mixHash(uint64(k))
+		case uint16:
+			return mixHash(uint64(k))
+		case uint32:
+			return mixHash(uint64(k))
+		case uint64:
+			return mixHash(k)
+		case uintptr:
+			return mixHash(uint64(k))
+		case float32:
+			return hashFloat(float64(k))
+		case float64:
+			return hashFloat(k)
+		default:
+			return hashValue(seed, reflect.ValueOf(key))
+		}
+	}
+}
+
+func hashString(seed maphash.Seed, s string) uint64 {
+	var h maphash.Hash
+
+	h.SetSeed(seed)
+	_, _ = h.WriteString(s)
+
+	return h.Sum64()
+}
+
+// hashValue hashes a comparable value by its kind, so named types hash like their underlying types.
+// Values equal by == get equal hashes: floats are hashed with -0 normalized to 0,
+// pointers and channels by address and composite values by their elements.
+func hashValue(seed maphash.Seed, v reflect.Value) uint64 {
+	switch v.Kind() {
+	case reflect.String:
+		return hashString(seed, v.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return mixHash(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return mixHash(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return hashFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+
+		return mixHash(hashFloat(real(c))) + hashFloat(imag(c))
+	case reflect.Bool:
+		if v.Bool() {
+			return mixHash(1)
+		}
+
+		return mixHash(0)
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return mixHash(uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+
+		return hashValue(seed, v.Elem())
+	case reflect.Array:
+		h := uint64(v.Len())
+		for i := 0; i < v.Len(); i++ {
+			h = mixHash(h + hashValue(seed, v.Index(i)))
+		}
+
+		return h
+	case reflect.Struct:
+		h := uint64(v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			h = mixHash(h + hashValue(seed, v.Field(i)))
+		}
+
+		return h
+	default:
+		// A nil interface key has no kind.
+		return 0
+	}
+}
+
+// hashFloat hashes a float by its bits, both zeros are equal keys and must share a hash.
+func hashFloat(f float64) uint64 {
+	if f == 0 {
+		f = 0
+	}
+
+	return mixHash(math.Float64bits(f))
+}
+
+// mixHash spreads integer keys across shards, it's the finalizer of SplitMix64.
+func mixHash(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+
+	return x
+}
+
+func (m *ShardedMap[I, K]) shard(key I) *mapShard[I, K] {
+	return m.shards[m.hash(key)%uint64(len(m.shards))]
+}
+
+// Load returns the value stored for the key and whether it was found.
+func (m *ShardedMap[I, K]) Load(key I) (K, bool) {
+	s := m.shard(key)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	v, ok := s.m[key]
+
+	return v, ok
+}
+
+// Store sets the value for the key.
+func (m *ShardedMap[I, K]) Store(key I, value K) {
+	s := m.shard(key)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.m[key] = value
+}
+
+// LoadOrStore returns the existing value for the key if present and true.
+// Otherwise, it stores the provided value and returns it and false.
+func (m *ShardedMap[I, K]) LoadOrStore(key I, value K) (K, bool) {
+	s := m.shard(key)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if v, ok := s.m[key]; ok {
+		return v, true
+	}
+
+	s.m[key] = value
+
+	return value, false
+}
+
+// Delete removes the value for the key.
+func (m *ShardedMap[I, K]) Delete(key I) {
+	s := m.shard(key)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.m, key)
+}
+
+// LoadAndDelete removes the value for the key, returning the previous value if any and whether it was found.
+func (m *ShardedMap[I, K]) LoadAndDelete(key I) (K, bool) {
+	s := m.shard(key)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.m[key]
+	delete(s.m, key)
+
+	return v, ok
+}
+
+// Compute atomically updates the value for the key.
+// The callback receives the current value and whether it exists, and returns the new value
+// and whether it should be kept; if keep is false the key is deleted.
+// The shard is locked while the callback runs, so it must not access the map.
+// Returns the new value and whether it was kept.
+func (m *ShardedMap[I, K]) Compute(key I, callback func(value K, loaded bool) (K, bool)) (K, bool) {
+	s := m.shard(key)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, loaded := s.m[key]
+
+	v, keep := callback(old, loaded)
+	if !keep {
+		delete(s.m, key)
+
+		return v, false
+	}
+
+	s.m[key] = v
+
+	return v, true
+}
+
+// Len returns the number of entries in the map.
+func (m *ShardedMap[I, K]) Len() int {
+	n := 0
+
+	for _, s := range m.shards {
+		s.mu.RLock()
+		n += len(s.m)
+		s.mu.RUnlock()
+	}
+
+	return n
+}
+
+// snapshot copies every shard under its read lock and calls the callback with the copy without holding the lock.
+// Each shard is consistent on its own, changes made to other shards during the walk may or may not be seen.
+func (m *ShardedMap[I, K]) snapshot(callback func(shard map[I]K)) {
+	for _, s := range m.shards {
+		s.mu.RLock()
+
+		c := make(map[I]K, len(s.m))
+		for k, v := range s.m {
+			c[k] = v
+		}
+
+		s.mu.RUnlock()
+
+		callback(c)
+	}
+}
+
+// Walk creates a new array populated with the results of calling a provided function on every entry.
+// Entries are taken from per-shard snapshots, the callback may safely access the map.
+func (m *ShardedMap[I, K]) Walk(callback func(key I, value K) any) []any {
+	return ShardedMapWalk(m, callback)
+}
+
+// ShardedMapWalk creates a new array populated with the results of calling a provided function on every entry
+// of the sharded map. It's a typed version of ShardedMap.Walk, like MapWalk for plain maps.
+func ShardedMapWalk[I comparable, K, T any](m *ShardedMap[I, K], callback func(key I, value K) T) []T {
+	r := make([]T, 0)
+
+	m.snapshot(func(shard map[I]K) {
+		r = append(r, MapWalk(shard, callback)...)
+	})
+
+	return r
+}
+
+// ForEach executes a provided function once for each entry.
+// Entries are taken from per-shard snapshots, the callback may safely access the map.
+func (m *ShardedMap[I, K]) ForEach(callback func(key I, value K)) {
+	m.snapshot(func(shard map[I]K) {
+		MapForEach(shard, callback)
+	})
+}
+
+// Filter creates a plain map with the entries that pass the test implemented by the provided function.
+// Entries are taken from per-shard snapshots, the callback may safely access the map.
+func (m *ShardedMap[I, K]) Filter(callback func(key I, value K) bool) map[I]K {
+	r := make(map[I]K)
+
+	m.snapshot(func(shard map[I]K) {
+		for k, v := range MapFilter(shard, callback) {
+			r[k] = v
+		}
+	})
+
+	return r
+}
+
+// Keys returns all keys of the map.
+func (m *ShardedMap[I, K]) Keys() []I {
+	r := make([]I, 0)
+
+	m.snapshot(func(shard map[I]K) {
+		r = append(r, MapKeys(shard)...)
+	})
+
+	return r
+}
+
+// Values returns all values of the map.
+func (m *ShardedMap[I, K]) Values() []K {
+	r := make([]K, 0)
+
+	m.snapshot(func(shard map[I]K) {
+		r = append(r, MapValues(shard)...)
+	})
+
+	return r
+}
+
+// ToMap returns a plain map with all entries.
+func (m *ShardedMap[I, K]) ToMap() map[I]K {
+	return m.Filter(func(I, K) bool {
+		return true
+	})
+}
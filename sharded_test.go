@@ -0,0 +1,307 @@
+package arrays_test
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+func TestShardedMap(t *testing.T) {
+	t.Parallel()
+
+	m := arrays.NewShardedMap[string, int](4, nil)
+
+	m.Store("a", 1)
+	m.Store("b", 2)
+
+	if v, ok := m.Load("a"); !ok || v != 1 {
+		t.Errorf("Load(a) = %v, %v, want 1, true", v, ok)
+	}
+
+	if _, ok := m.Load("missing"); ok {
+		t.Error("Load(missing) found a value")
+	}
+
+	if v, loaded := m.LoadOrStore("a", 10); !loaded || v != 1 {
+		t.Errorf("LoadOrStore(a) = %v, %v, want 1, true", v, loaded)
+	}
+
+	if v, loaded := m.LoadOrStore("c", 3); loaded || v != 3 {
+		t.Errorf("LoadOrStore(c) = %v, %v, want 3, false", v, loaded)
+	}
+
+	if v, ok := m.LoadAndDelete("c"); !ok || v != 3 {
+		t.Errorf("LoadAndDelete(c) = %v, %v, want 3, true", v, ok)
+	}
+
+	m.Delete("b")
+
+	if m.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", m.Len())
+	}
+}
+
+func TestShardedMapCompute(t *testing.T) {
+	t.Parallel()
+
+	m := arrays.NewShardedMap[int, int](0, nil)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			m.Compute(i%10, func(v int, _ bool) (int, bool) {
+				return v + 1, true
+			})
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i := 0; i < 10; i++ {
+		if v, _ := m.Load(i); v != 10 {
+			t.Errorf("Load(%d) = %d, want 10", i, v)
+		}
+	}
+
+	if v, kept := m.Compute(0, func(int, bool) (int, bool) { return 0, false }); kept || v != 0 {
+		t.Errorf("Compute() = %v, %v, want 0, false", v, kept)
+	}
+
+	if _, ok := m.Load(0); ok {
+		t.Error("Compute() did not delete the key")
+	}
+}
+
+func TestShardedMapHelpers(t *testing.T) {
+	t.Parallel()
+
+	type key struct {
+		group string
+		id    int
+	}
+
+	m := arrays.NewShardedMap[key, int](8, nil)
+	for i := 0; i < 20; i++ {
+		m.Store(key{group: "g", id: i}, i)
+	}
+
+	keys := m.Keys()
+	if len(keys) != 20 {
+		t.Errorf("Keys() returned %d keys, want 20", len(keys))
+	}
+
+	values := m.Values()
+	sort.Ints(values)
+
+	for i, v := range values {
+		if v != i {
+			t.Errorf("got value %d, want %d", v, i)
+		}
+	}
+
+	even := m.Filter(func(_ key, v int) bool { return v%2 == 0 })
+	if len(even) != 10 {
+		t.Errorf("Filter() returned %d entries, want 10", len(even))
+	}
+
+	labels := arrays.ShardedMapWalk(m, func(k key, v int) string {
+		return fmt.Sprintf("%s%d=%d", k.group, k.id, v)
+	})
+	if len(labels) != 20 {
+		t.Errorf("ShardedMapWalk() returned %d labels, want 20", len(labels))
+	}
+
+	if walked := m.Walk(func(k key, _ int) any { return k }); len(walked) != 20 {
+		t.Errorf("Walk() returned %d values, want 20", len(walked))
+	}
+
+	// Callbacks run on snapshots, so they may modify the map.
+	m.ForEach(func(k key, v int) {
+		m.Store(k, v*2)
+	})
+
+	if got := m.ToMap(); len(got) != 20 || got[key{group: "g", id: 3}] != 6 {
+		t.Errorf("ToMap() = %v", got)
+	}
+}
+
+func TestShardedMapCustomHash(t *testing.T) {
+	t.Parallel()
+
+	m := arrays.NewShardedMap[string, int](4, func(key string) uint64 {
+		return uint64(len(key))
+	})
+
+	m.Store("a", 1)
+	m.Store("bb", 2)
+
+	if v, ok := m.Load("bb"); !ok || v != 2 {
+		t.Errorf("Load(bb) = %v, %v, want 2, true", v, ok)
+	}
+}
+
+func TestShardedMapEqualKeys(t *testing.T) {
+	t.Parallel()
+
+	floats := arrays.NewShardedMap[float64, string](64, nil)
+	floats.Store(0.0, "zero")
+	floats.Store(math.Copysign(0, -1), "negative zero")
+
+	if v, ok := floats.Load(math.Copysign(0, -1)); !ok || v != "negative zero" || floats.Len() != 1 {
+		t.Errorf("Load(-0) = %v, %v, Len() = %d, want negative zero, true, 1", v, ok, floats.Len())
+	}
+
+	type point struct {
+		X, Y float64
+		Tag  string
+	}
+
+	points := arrays.NewShardedMap[point, int](64, nil)
+	points.Store(point{X: 0, Tag: "a"}, 1)
+
+	if v, ok := points.Load(point{X: math.Copysign(0, -1), Tag: "a"}); !ok || v != 1 {
+		t.Errorf("Load(point) = %v, %v, want 1, true", v, ok)
+	}
+}
+
+type userKey string
+
+// TestShardedMapNamedKeysDoNotAllocate isn't parallel, AllocsPerRun can't be used in parallel tests.
+func TestShardedMapNamedKeysDoNotAllocate(t *testing.T) {
+	ids := arrays.NewShardedMap[userID, int](0, nil)
+	names := arrays.NewShardedMap[userKey, int](0, nil)
+
+	ids.Store(1<<40, 1)
+	names.Store("name", 1)
+
+	// Boxing the key for reflect may allocate once, formatting it would allocate several times.
+	allocs := testing.AllocsPerRun(100, func() {
+		ids.Load(1 << 40)
+		names.Load("name")
+	})
+
+	if allocs > 2 {
+		t.Errorf("Load() allocates %v times, want at most 2", allocs)
+	}
+}
+
+// TestShardedMapBuiltinKeysDoNotAllocate isn't parallel, AllocsPerRun can't be used in parallel tests.
+func TestShardedMapBuiltinKeysDoNotAllocate(t *testing.T) {
+	strs := arrays.NewShardedMap[string, int](0, nil)
+	ints := arrays.NewShardedMap[int64, int](0, nil)
+	floats := arrays.NewShardedMap[float64, int](0, nil)
+
+	key := strconv.Itoa(1 << 20)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		strs.Store(key, 1)
+		strs.Load(key)
+		ints.Store(1<<40, 1)
+		ints.Load(1 << 40)
+		floats.Store(0.5, 1)
+		floats.Delete(0.5)
+	})
+
+	if allocs != 0 {
+		t.Errorf("built-in keys allocate %v times, want 0", allocs)
+	}
+}
+
+const benchmarkKeys = 1024
+
+func benchmarkKeysList() []string {
+	keys := make([]string, benchmarkKeys)
+	for i := range keys {
+		keys[i] = "key-" + strconv.Itoa(i)
+	}
+
+	return keys
+}
+
+func BenchmarkShardedMapReadHeavy(b *testing.B) {
+	keys := benchmarkKeysList()
+	m := arrays.NewShardedMap[string, int](0, nil)
+
+	for i, k := range keys {
+		m.Store(k, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			k := keys[i%benchmarkKeys]
+			if i%10 == 0 {
+				m.Store(k, i)
+			} else {
+				m.Load(k)
+			}
+		}
+	})
+}
+
+func BenchmarkSyncMapReadHeavy(b *testing.B) {
+	keys := benchmarkKeysList()
+
+	var m sync.Map
+
+	for i, k := range keys {
+		m.Store(k, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			k := keys[i%benchmarkKeys]
+			if i%10 == 0 {
+				m.Store(k, i)
+			} else {
+				m.Load(k)
+			}
+		}
+	})
+}
+
+func BenchmarkShardedMapWriteHeavy(b *testing.B) {
+	keys := benchmarkKeysList()
+	m := arrays.NewShardedMap[string, int](0, nil)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			k := keys[i%benchmarkKeys]
+			if i%10 == 0 {
+				m.Load(k)
+			} else {
+				m.Store(k, i)
+			}
+		}
+	})
+}
+
+func BenchmarkSyncMapWriteHeavy(b *testing.B) {
+	keys := benchmarkKeysList()
+
+	var m sync.Map
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			k := keys[i%benchmarkKeys]
+			if i%10 == 0 {
+				m.Load(k)
+			} else {
+				m.Store(k, i)
+			}
+		}
+	})
+}
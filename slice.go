@@ -1,7 +1,5 @@
 package arrays
 
-import "fmt"
-
 // ArrayMap creates a new array populated with the results of calling a provided function
 // on every element in the calling array.
 func ArrayMap[I, T any](arr []I, callback func(key int, value I) T) []T {
@@ -17,18 +15,29 @@ func ArrayMap[I, T any](arr []I, callback func(key int, value I) T) []T {
 // ArrayMapErr creates a new array populated with the results of calling a provided function
 // on every element in the calling array.
 // Returns first error, if callback fails.
-func ArrayMapErr[I, T any](arr []I, callback func(key int, value I) (T, error)) ([]T, error) {
+// Accepts WithContext, WithRetry, WithRateLimit, WithProcessClock, WithSleeper and WithHooks.
+func ArrayMapErr[I, T any](arr []I, callback func(key int, value I) (T, error), opts ...ProcessOption) ([]T, error) {
+	c := newProcessConfig(opts)
+	start := c.begin(len(arr))
 	r := make([]T, 0, len(arr))
 
 	for i, v := range arr {
-		res, err := callback(i, v)
+		i := i
+
+		res, err := processElement(c, i, v, func(value I) (T, error) {
+			return callback(i, value)
+		})
 		if err != nil {
-			return nil, fmt.Errorf("callback: %w", err)
+			c.finish(start, err)
+
+			return nil, err
 		}
 
 		r = append(r, res)
 	}
 
+	c.finish(start, nil)
+
 	return r, nil
 }
 
@@ -178,17 +187,93 @@ func ArrayProcess[I, T any](arr []I, callback func(value I) T) []T {
 // ArrayProcessErr creates a new array populated with the results of calling a provided function
 // on every element in the calling array.
 // Returns first error, if callback fails.
-func ArrayProcessErr[I, T any](arr []I, callback func(value I) (T, error)) ([]T, error) {
+// Accepts WithContext, WithRetry, WithRateLimit, WithProcessClock, WithSleeper and WithHooks.
+func ArrayProcessErr[I, T any](arr []I, callback func(value I) (T, error), opts ...ProcessOption) ([]T, error) {
+	c := newProcessConfig(opts)
+	start := c.begin(len(arr))
 	r := make([]T, 0, len(arr))
 
-	for _, v := range arr {
-		res, err := callback(v)
+	for i, v := range arr {
+		res, err := processElement(c, i, v, callback)
 		if err != nil {
-			return nil, fmt.Errorf("callback: %w", err)
+			c.finish(start, err)
+
+			return nil, err
 		}
 
 		r = append(r, res)
 	}
 
+	c.finish(start, nil)
+
 	return r, nil
 }
+
+// ArrayCompact creates a new array with consecutive duplicate elements collapsed into a single element.
+// Unlike ArrayUniq, equal elements that are not adjacent are kept, and the order is preserved.
+func ArrayCompact[I comparable](arr []I) []I {
+	return ArrayCompactBy(arr, func(a, b I) bool {
+		return a == b
+	})
+}
+
+// ArrayCompactBy creates a new array with consecutive duplicate elements collapsed into a single element.
+// Elements are compared with the provided equal function, the first element of every run is kept.
+func ArrayCompactBy[I any](arr []I, equal func(a, b I) bool) []I {
+	r := make([]I, 0, len(arr))
+
+	for i, v := range arr {
+		if i > 0 && equal(r[len(r)-1], v) {
+			continue
+		}
+
+		r = append(r, v)
+	}
+
+	return r
+}
+
+// Run is a single run of equal consecutive values produced by ArrayRunLengthEncode.
+type Run[I any] struct {
+	Value I
+	Count int
+}
+
+// ArrayRunLengthEncode creates a new array of runs, one for every sequence of equal consecutive elements.
+func ArrayRunLengthEncode[I comparable](arr []I) []Run[I] {
+	r := make([]Run[I], 0)
+
+	for i, v := range arr {
+		if i > 0 && r[len(r)-1].Value == v {
+			r[len(r)-1].Count++
+
+			continue
+		}
+
+		r = append(r, Run[I]{Value: v, Count: 1})
+	}
+
+	return r
+}
+
+// ArrayRunLengthDecode creates a new array by repeating the value of every run Count times.
+// Runs with non-positive Count are skipped.
+func ArrayRunLengthDecode[I any](runs []Run[I]) []I {
+	size := 0
+
+	for _, run := range runs {
+		if run.Count > 0 {
+			size += run.Count
+		}
+	}
+
+	r := make([]I, 0, size)
+
+	for _, run := range runs {
+		for i := 0; i < run.Count; i++ {
+			r = append(r, run.Value)
+		}
+	}
+
+	return r
+}
@@ -802,3 +802,210 @@ func TestArrayProcessErr(t *testing.T) {
 		})
 	}
 }
+
+func TestArrayCompact(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		arr  []int
+		want []int
+	}{
+		{
+			name: "collapse consecutive duplicates",
+			arr:  []int{1, 1, 2, 2, 2, 3, 1, 1},
+			want: []int{1, 2, 3, 1},
+		},
+		{
+			name: "no duplicates",
+			arr:  []int{1, 2, 3},
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "all equal",
+			arr:  []int{7, 7, 7},
+			want: []int{7},
+		},
+		{
+			name: "empty array",
+			arr:  []int{},
+			want: []int{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := arrays.ArrayCompact(tt.arr)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+
+			for i, v := range tt.want {
+				if got[i] != v {
+					t.Errorf("got %v, want %v", got[i], v)
+				}
+			}
+		})
+	}
+}
+
+func TestArrayCompactBy(t *testing.T) {
+	t.Parallel()
+
+	type event struct {
+		kind string
+		id   int
+	}
+
+	tests := []struct {
+		name  string
+		arr   []event
+		equal func(a, b event) bool
+		want  []event
+	}{
+		{
+			name: "collapse by kind keeping first of run",
+			arr: []event{
+				{kind: "start", id: 1},
+				{kind: "tick", id: 2},
+				{kind: "tick", id: 3},
+				{kind: "stop", id: 4},
+				{kind: "tick", id: 5},
+			},
+			equal: func(a, b event) bool { return a.kind == b.kind },
+			want: []event{
+				{kind: "start", id: 1},
+				{kind: "tick", id: 2},
+				{kind: "stop", id: 4},
+				{kind: "tick", id: 5},
+			},
+		},
+		{
+			name:  "empty array",
+			arr:   []event{},
+			equal: func(a, b event) bool { return a.kind == b.kind },
+			want:  []event{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := arrays.ArrayCompactBy(tt.arr, tt.equal)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+
+			for i, v := range tt.want {
+				if got[i] != v {
+					t.Errorf("got %v, want %v", got[i], v)
+				}
+			}
+		})
+	}
+}
+
+func TestArrayRunLengthEncode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		arr  []string
+		want []arrays.Run[string]
+	}{
+		{
+			name: "encode runs",
+			arr:  []string{"a", "a", "b", "c", "c", "c", "a"},
+			want: []arrays.Run[string]{
+				{Value: "a", Count: 2},
+				{Value: "b", Count: 1},
+				{Value: "c", Count: 3},
+				{Value: "a", Count: 1},
+			},
+		},
+		{
+			name: "empty array",
+			arr:  []string{},
+			want: []arrays.Run[string]{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := arrays.ArrayRunLengthEncode(tt.arr)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+
+			for i, v := range tt.want {
+				if got[i] != v {
+					t.Errorf("got %v, want %v", got[i], v)
+				}
+			}
+
+			decoded := arrays.ArrayRunLengthDecode(got)
+
+			if len(decoded) != len(tt.arr) {
+				t.Fatalf("decoded %v, want %v", decoded, tt.arr)
+			}
+
+			for i, v := range tt.arr {
+				if decoded[i] != v {
+					t.Errorf("decoded %v, want %v", decoded[i], v)
+				}
+			}
+		})
+	}
+}
+
+func TestArrayRunLengthDecode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		runs []arrays.Run[int]
+		want []int
+	}{
+		{
+			name: "decode runs",
+			runs: []arrays.Run[int]{{Value: 1, Count: 2}, {Value: 2, Count: 3}},
+			want: []int{1, 1, 2, 2, 2},
+		},
+		{
+			name: "skip non-positive counts",
+			runs: []arrays.Run[int]{{Value: 1, Count: 0}, {Value: 2, Count: -1}, {Value: 3, Count: 1}},
+			want: []int{3},
+		},
+		{
+			name: "empty runs",
+			runs: []arrays.Run[int]{},
+			want: []int{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := arrays.ArrayRunLengthDecode(tt.runs)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+
+			for i, v := range tt.want {
+				if got[i] != v {
+					t.Errorf("got %v, want %v", got[i], v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package arrays
+
+import "sort"
+
+// ArrayBinarySearch searches for the target in an array sorted in ascending order.
+// Returns the index of the first element equal to the target and true,
+// or the index the target would be inserted at and false.
+func ArrayBinarySearch[T Ordered](arr []T, target T) (int, bool) {
+	return ArrayBinarySearchFunc(arr, target, compareOrdered[T])
+}
+
+// ArrayBinarySearchFunc searches for the target in an array sorted in ascending order by the cmp function,
+// which returns a negative number if a < b, zero if a == b and a positive number if a > b.
+// Returns the index of the first element equal to the target and true,
+// or the index the target would be inserted at and false.
+func ArrayBinarySearchFunc[T any](arr []T, target T, cmp func(a, b T) int) (int, bool) {
+	i := sort.Search(len(arr), func(i int) bool {
+		return cmp(arr[i], target) >= 0
+	})
+
+	return i, i < len(arr) && cmp(arr[i], target) == 0
+}
+
+// SortedSlice is a slice kept sorted by a comparator, searches take O(log n).
+// Equal elements keep their insertion order. It's not safe for concurrent use.
+type SortedSlice[T any] struct {
+	items []T
+	cmp   func(a, b T) int
+}
+
+// NewSortedSlice creates a sorted slice with a copy of the values, ordered by the cmp function
+// which returns a negative number if a < b, zero if a == b and a positive number if a > b.
+func NewSortedSlice[T any](cmp func(a, b T) int, values ...T) *SortedSlice[T] {
+	s := &SortedSlice[T]{items: make([]T, len(values)), cmp: cmp}
+	copy(s.items, values)
+
+	sort.SliceStable(s.items, func(i, j int) bool {
+		return cmp(s.items[i], s.items[j]) < 0
+	})
+
+	return s
+}
+
+// Len returns the number of elements.
+func (s *SortedSlice[T]) Len() int {
+	return len(s.items)
+}
+
+// At returns the element at the index, false if the index is out of range.
+func (s *SortedSlice[T]) At(i int) (T, bool) {
+	if i < 0 || i >= len(s.items) {
+		return *new(T), false
+	}
+
+	return s.items[i], true
+}
+
+// LowerBound returns the index of the first element that is not less than the value.
+func (s *SortedSlice[T]) LowerBound(value T) int {
+	return sort.Search(len(s.items), func(i int) bool {
+		return s.cmp(s.items[i], value) >= 0
+	})
+}
+
+// UpperBound returns the index of the first element that is greater than the value.
+func (s *SortedSlice[T]) UpperBound(value T) int {
+	return sort.Search(len(s.items), func(i int) bool {
+		return s.cmp(s.items[i], value) > 0
+	})
+}
+
+// IndexOf returns the index of the first element equal to the value, -1 and false if there is none.
+func (s *SortedSlice[T]) IndexOf(value T) (int, bool) {
+	i := s.LowerBound(value)
+	if i < len(s.items) && s.cmp(s.items[i], value) == 0 {
+		return i, true
+	}
+
+	return -1, false
+}
+
+// Contains reports whether an element equal to the value is present.
+func (s *SortedSlice[T]) Contains(value T) bool {
+	_, ok := s.IndexOf(value)
+
+	return ok
+}
+
+// Insert adds the values keeping the order, after existing equal elements.
+// Every insert takes O(log n) to search and O(n) to shift elements.
+func (s *SortedSlice[T]) Insert(values ...T) {
+	for _, v := range values {
+		i := s.UpperBound(v)
+
+		s.items = append(s.items, *new(T))
+		copy(s.items[i+1:], s.items[i:])
+		s.items[i] = v
+	}
+}
+
+// Delete removes the first element equal to the value, returns false if there is none.
+func (s *SortedSlice[T]) Delete(value T) bool {
+	i, ok := s.IndexOf(value)
+	if !ok {
+		return false
+	}
+
+	s.DeleteAt(i)
+
+	return true
+}
+
+// DeleteAt removes the element at the index, returns false if the index is out of range.
+func (s *SortedSlice[T]) DeleteAt(i int) bool {
+	if i < 0 || i >= len(s.items) {
+		return false
+	}
+
+	copy(s.items[i:], s.items[i+1:])
+	s.items[len(s.items)-1] = *new(T)
+	s.items = s.items[:len(s.items)-1]
+
+	return true
+}
+
+// Range returns elements e with from <= e < to as a subslice sharing memory with the sorted slice.
+// The result must not be modified and is invalidated by inserts and deletes.
+func (s *SortedSlice[T]) Range(from, to T) []T {
+	lo, hi := s.LowerBound(from), s.LowerBound(to)
+	if hi < lo {
+		hi = lo
+	}
+
+	return s.items[lo:hi:hi]
+}
+
+// RangeClosed returns elements e with from <= e <= to as a subslice sharing memory with the sorted slice.
+// The result must not be modified and is invalidated by inserts and deletes.
+func (s *SortedSlice[T]) RangeClosed(from, to T) []T {
+	lo, hi := s.LowerBound(from), s.UpperBound(to)
+	if hi < lo {
+		hi = lo
+	}
+
+	return s.items[lo:hi:hi]
+}
+
+// ToSlice returns a copy of the elements in order.
+func (s *SortedSlice[T]) ToSlice() []T {
+	r := make([]T, len(s.items))
+	copy(r, s.items)
+
+	return r
+}
@@ -0,0 +1,142 @@
+package arrays_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+func intCmp(a, b int) int {
+	return a - b
+}
+
+func TestArrayBinarySearch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		arr       []int
+		target    int
+		wantIndex int
+		wantFound bool
+	}{
+		{
+			name:      "found",
+			arr:       []int{1, 3, 5, 7},
+			target:    5,
+			wantIndex: 2,
+			wantFound: true,
+		},
+		{
+			name:      "first of duplicates",
+			arr:       []int{1, 3, 3, 3, 7},
+			target:    3,
+			wantIndex: 1,
+			wantFound: true,
+		},
+		{
+			name:      "insertion point",
+			arr:       []int{1, 3, 5, 7},
+			target:    4,
+			wantIndex: 2,
+			wantFound: false,
+		},
+		{
+			name:      "after last",
+			arr:       []int{1, 3},
+			target:    9,
+			wantIndex: 2,
+			wantFound: false,
+		},
+		{
+			name:      "empty array",
+			arr:       []int{},
+			target:    1,
+			wantIndex: 0,
+			wantFound: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotIndex, gotFound := arrays.ArrayBinarySearch(tt.arr, tt.target)
+
+			if gotIndex != tt.wantIndex || gotFound != tt.wantFound {
+				t.Errorf("got %v, %v, want %v, %v", gotIndex, gotFound, tt.wantIndex, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestSortedSlice(t *testing.T) {
+	t.Parallel()
+
+	s := arrays.NewSortedSlice(intCmp, 5, 1, 4, 1, 3)
+
+	if got := s.ToSlice(); !reflect.DeepEqual(got, []int{1, 1, 3, 4, 5}) {
+		t.Fatalf("got %v, want [1 1 3 4 5]", got)
+	}
+
+	s.Insert(2, 6, 0, 4)
+
+	if got := s.ToSlice(); !reflect.DeepEqual(got, []int{0, 1, 1, 2, 3, 4, 4, 5, 6}) {
+		t.Fatalf("got %v, want [0 1 1 2 3 4 4 5 6]", got)
+	}
+
+	if i, ok := s.IndexOf(4); !ok || i != 5 {
+		t.Errorf("IndexOf(4) = %v, %v, want 5, true", i, ok)
+	}
+
+	if i, ok := s.IndexOf(10); ok || i != -1 {
+		t.Errorf("IndexOf(10) = %v, %v, want -1, false", i, ok)
+	}
+
+	if s.LowerBound(4) != 5 || s.UpperBound(4) != 7 {
+		t.Errorf("LowerBound(4) = %d, UpperBound(4) = %d, want 5, 7", s.LowerBound(4), s.UpperBound(4))
+	}
+
+	if got := s.Range(1, 4); !reflect.DeepEqual(got, []int{1, 1, 2, 3}) {
+		t.Errorf("Range(1, 4) = %v, want [1 1 2 3]", got)
+	}
+
+	if got := s.RangeClosed(1, 4); !reflect.DeepEqual(got, []int{1, 1, 2, 3, 4, 4}) {
+		t.Errorf("RangeClosed(1, 4) = %v, want [1 1 2 3 4 4]", got)
+	}
+
+	if got := s.Range(5, 2); len(got) != 0 {
+		t.Errorf("Range(5, 2) = %v, want empty", got)
+	}
+
+	if !s.Delete(1) || !s.Contains(1) || s.Delete(100) {
+		t.Error("Delete() removed wrong number of elements")
+	}
+
+	if !s.DeleteAt(0) || s.DeleteAt(100) {
+		t.Error("DeleteAt() returned unexpected result")
+	}
+
+	if v, ok := s.At(0); !ok || v != 1 || s.Len() != 7 {
+		t.Errorf("At(0) = %v, %v, Len() = %d, want 1, true, 7", v, ok, s.Len())
+	}
+}
+
+func TestSortedSliceStable(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		key  int
+		name string
+	}
+
+	s := arrays.NewSortedSlice(func(a, b item) int { return a.key - b.key },
+		item{key: 2, name: "first"}, item{key: 1, name: "x"}, item{key: 2, name: "second"})
+	s.Insert(item{key: 2, name: "third"})
+
+	got := arrays.ArrayProcess(s.RangeClosed(item{key: 2}, item{key: 2}), func(v item) string { return v.name })
+	if !reflect.DeepEqual(got, []string{"first", "second", "third"}) {
+		t.Errorf("got %v, want [first second third]", got)
+	}
+}
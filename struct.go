@@ -0,0 +1,414 @@
+package arrays
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	// ErrNotStruct is returned when a value passed to StructToMap or MapToStruct is not a struct.
+	ErrNotStruct = errors.New("value is not a struct")
+	// ErrUnknownField is returned by MapToStruct in strict mode when a key doesn't match any field.
+	ErrUnknownField = errors.New("unknown field")
+	// ErrFieldType is returned by MapToStruct in strict mode when a value can't be assigned to a field.
+	ErrFieldType = errors.New("mistyped field")
+)
+
+const defaultStructTag = "json"
+
+type structConfig struct {
+	tagName string
+	strict  bool
+}
+
+// StructOption configures StructToMap and MapToStruct.
+type StructOption func(*structConfig)
+
+// WithStructTag sets the struct tag used for field names, "json" by default.
+func WithStructTag(name string) StructOption {
+	return func(c *structConfig) {
+		c.tagName = name
+	}
+}
+
+// WithStructStrict makes MapToStruct fail on unknown keys and values that can't be assigned to their fields.
+func WithStructStrict() StructOption {
+	return func(c *structConfig) {
+		c.strict = true
+	}
+}
+
+func newStructConfig(opts []StructOption) structConfig {
+	c := structConfig{tagName: defaultStructTag}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+type structField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+// structFields lists exported fields of a struct type by their tag names.
+// Untagged embedded structs are flattened, fields of the outer struct take precedence.
+func structFields(t reflect.Type, tagName string) []structField {
+	r := make([]structField, 0, t.NumField())
+	seen := make(map[string]bool, t.NumField())
+	embedded := make([]reflect.StructField, 0)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get(tagName)
+		if tag == "-" {
+			continue
+		}
+
+		name, flags, _ := strings.Cut(tag, ",")
+
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			// Unexported embedded pointers can't be allocated through reflection.
+			if !f.IsExported() && f.Type.Kind() == reflect.Pointer {
+				continue
+			}
+
+			embedded = append(embedded, f)
+
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		seen[name] = true
+		r = append(r, structField{
+			name:      name,
+			index:     f.Index,
+			omitEmpty: strings.Contains(","+flags+",", ",omitempty,"),
+		})
+	}
+
+	for _, f := range embedded {
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		for _, inner := range structFields(ft, tagName) {
+			if seen[inner.name] {
+				continue
+			}
+
+			seen[inner.name] = true
+			inner.index = append(append([]int(nil), f.Index...), inner.index...)
+			r = append(r, inner)
+		}
+	}
+
+	return r
+}
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// StructToMap converts a struct or a pointer to a struct into a map keyed by field tag names.
+// Nested structs become nested maps, pointers are dereferenced and slices become []any.
+// Values implementing encoding.TextMarshaler, such as time.Time, are kept as is.
+// Fields tagged with "-" are skipped, fields tagged with "omitempty" are skipped when empty.
+func StructToMap(v any, opts ...StructOption) (map[string]any, error) {
+	c := newStructConfig(opts)
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %T", ErrNotStruct, v)
+	}
+
+	return structToMap(rv, c), nil
+}
+
+func structToMap(rv reflect.Value, c structConfig) map[string]any {
+	fields := structFields(rv.Type(), c.tagName)
+	r := make(map[string]any, len(fields))
+
+	for _, f := range fields {
+		fv, ok := fieldByIndex(rv, f.index)
+		if !ok {
+			continue
+		}
+
+		if f.omitEmpty && fv.IsZero() {
+			continue
+		}
+
+		r[f.name] = toMapValue(fv, c)
+	}
+
+	return r
+}
+
+func fieldByIndex(rv reflect.Value, index []int) (reflect.Value, bool) {
+	for i, idx := range index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return reflect.Value{}, false
+			}
+
+			rv = rv.Elem()
+		}
+
+		rv = rv.Field(idx)
+	}
+
+	return rv, true
+}
+
+func toMapValue(rv reflect.Value, c structConfig) any {
+	if rv.Type().Implements(textMarshalerType) {
+		return rv.Interface()
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+
+		return toMapValue(rv.Elem(), c)
+	case reflect.Struct:
+		return structToMap(rv, c)
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return nil
+		}
+
+		r := make([]any, rv.Len())
+		for i := range r {
+			r[i] = toMapValue(rv.Index(i), c)
+		}
+
+		return r
+	case reflect.Map:
+		if rv.IsNil() || rv.Type().Key().Kind() != reflect.String {
+			return rv.Interface()
+		}
+
+		r := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+
+		for iter.Next() {
+			r[iter.Key().String()] = toMapValue(iter.Value(), c)
+		}
+
+		return r
+	default:
+		return rv.Interface()
+	}
+}
+
+// MapToStruct populates a struct pointed to by dst with values from a map keyed by field tag names.
+// Nested maps fill nested structs, pointers are allocated when needed, numbers are converted
+// between numeric types when no precision is lost and strings are parsed by fields implementing
+// encoding.TextUnmarshaler, so maps decoded from JSON can be applied.
+// By default unknown keys and values of a wrong type are ignored, use WithStructStrict to fail on them.
+func MapToStruct(arr map[string]any, dst any, opts ...StructOption) error {
+	c := newStructConfig(opts)
+
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T is not a non-nil struct pointer", ErrNotStruct, dst)
+	}
+
+	return mapToStruct(arr, rv.Elem(), c, "")
+}
+
+func mapToStruct(arr map[string]any, rv reflect.Value, c structConfig, prefix string) error {
+	fields := structFields(rv.Type(), c.tagName)
+	byName := make(map[string]structField, len(fields))
+
+	for _, f := range fields {
+		byName[f.name] = f
+	}
+
+	for k, v := range arr {
+		path := prefix + k
+
+		f, ok := byName[k]
+		if !ok {
+			if c.strict {
+				return fmt.Errorf("field %q: %w", path, ErrUnknownField)
+			}
+
+			continue
+		}
+
+		if err := assignValue(allocFieldByIndex(rv, f.index), v, c, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func allocFieldByIndex(rv reflect.Value, index []int) reflect.Value {
+	for i, idx := range index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+
+			rv = rv.Elem()
+		}
+
+		rv = rv.Field(idx)
+	}
+
+	return rv
+}
+
+func assignValue(dst reflect.Value, v any, c structConfig, path string) error {
+	if v == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+
+		return nil
+	}
+
+	src := reflect.ValueOf(v)
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+
+		return nil
+	}
+
+	// Values kept as TextMarshaler by StructToMap come back as strings after a JSON round trip.
+	if src.Kind() == reflect.String && dst.CanAddr() && dst.Addr().Type().Implements(textUnmarshalerType) {
+		u, _ := dst.Addr().Interface().(encoding.TextUnmarshaler)
+
+		err := u.UnmarshalText([]byte(src.String()))
+		if err != nil && c.strict {
+			return fmt.Errorf("field %q: %w: %v", path, ErrFieldType, err)
+		}
+
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(dst.Type().Elem())
+		if err := assignValue(elem.Elem(), v, c, path); err != nil {
+			return err
+		}
+
+		dst.Set(elem)
+
+		return nil
+	case reflect.Struct:
+		if m, ok := v.(map[string]any); ok {
+			return mapToStruct(m, dst, c, path+PathSeparator)
+		}
+	case reflect.Slice:
+		if src.Kind() == reflect.Slice || src.Kind() == reflect.Array {
+			r := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+			for i := 0; i < src.Len(); i++ {
+				itemPath := fmt.Sprintf("%s%s%d", path, PathSeparator, i)
+				if err := assignValue(r.Index(i), src.Index(i).Interface(), c, itemPath); err != nil {
+					return err
+				}
+			}
+
+			dst.Set(r)
+
+			return nil
+		}
+	case reflect.Array:
+		if (src.Kind() == reflect.Slice || src.Kind() == reflect.Array) && src.Len() == dst.Len() {
+			r := reflect.New(dst.Type()).Elem()
+			for i := 0; i < src.Len(); i++ {
+				itemPath := fmt.Sprintf("%s%s%d", path, PathSeparator, i)
+				if err := assignValue(r.Index(i), src.Index(i).Interface(), c, itemPath); err != nil {
+					return err
+				}
+			}
+
+			dst.Set(r)
+
+			return nil
+		}
+	case reflect.Map:
+		if m, ok := v.(map[string]any); ok && dst.Type().Key().Kind() == reflect.String {
+			r := reflect.MakeMapWithSize(dst.Type(), len(m))
+			for k, item := range m {
+				elem := reflect.New(dst.Type().Elem()).Elem()
+				if err := assignValue(elem, item, c, path+PathSeparator+k); err != nil {
+					return err
+				}
+
+				r.SetMapIndex(reflect.ValueOf(k).Convert(dst.Type().Key()), elem)
+			}
+
+			dst.Set(r)
+
+			return nil
+		}
+	default:
+		if converted, ok := convertNumber(src, dst.Type()); ok {
+			dst.Set(converted)
+
+			return nil
+		}
+	}
+
+	if c.strict {
+		return fmt.Errorf("field %q: %w: %T is not assignable to %s", path, ErrFieldType, v, dst.Type())
+	}
+
+	return nil
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return (k >= reflect.Int && k <= reflect.Float64) && k != reflect.Uintptr
+}
+
+// convertNumber converts a numeric value to another numeric type if no precision is lost.
+func convertNumber(src reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	if !isNumberKind(src.Kind()) || !isNumberKind(t.Kind()) {
+		return reflect.Value{}, false
+	}
+
+	isUint := t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uint64
+	isNegative := (src.CanInt() && src.Int() < 0) || (src.CanFloat() && src.Float() < 0)
+
+	if isUint && isNegative {
+		return reflect.Value{}, false
+	}
+
+	r := src.Convert(t)
+	if r.Convert(src.Type()).Interface() != src.Interface() {
+		return reflect.Value{}, false
+	}
+
+	return r, true
+}
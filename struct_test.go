@@ -0,0 +1,309 @@
+package arrays_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+type testAddress struct {
+	City string `json:"city"`
+	Zip  string `json:"zip,omitempty"`
+}
+
+type testMeta struct {
+	Source string `json:"source"`
+}
+
+type testUser struct {
+	testMeta
+	ID        int64             `json:"id"`
+	Name      string            `json:"name" db:"user_name"`
+	Email     *string           `json:"email"`
+	Address   testAddress       `json:"address"`
+	Previous  *testAddress      `json:"previous,omitempty"`
+	Tags      []string          `json:"tags"`
+	Labels    map[string]string `json:"labels"`
+	CreatedAt time.Time         `json:"created_at"`
+	Password  string            `json:"-"`
+	internal  int
+}
+
+func TestStructToMap(t *testing.T) {
+	t.Parallel()
+
+	email := "alice@example.com"
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	user := testUser{
+		testMeta:  testMeta{Source: "import"},
+		ID:        7,
+		Name:      "Alice",
+		Email:     &email,
+		Address:   testAddress{City: "Berlin"},
+		Tags:      []string{"admin"},
+		Labels:    map[string]string{"team": "core"},
+		CreatedAt: created,
+		Password:  "secret",
+		internal:  1,
+	}
+
+	want := map[string]any{
+		"source":     "import",
+		"id":         int64(7),
+		"name":       "Alice",
+		"email":      "alice@example.com",
+		"address":    map[string]any{"city": "Berlin"},
+		"tags":       []any{"admin"},
+		"labels":     map[string]any{"team": "core"},
+		"created_at": created,
+	}
+
+	got, err := arrays.StructToMap(&user)
+	if err != nil {
+		t.Fatalf("StructToMap() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestStructToMapTag(t *testing.T) {
+	t.Parallel()
+
+	got, err := arrays.StructToMap(testUser{Name: "Bob"}, arrays.WithStructTag("db"))
+	if err != nil {
+		t.Fatalf("StructToMap() error = %v", err)
+	}
+
+	if got["user_name"] != "Bob" {
+		t.Errorf("got %v, want user_name key", got)
+	}
+
+	if _, ok := got["Password"]; !ok {
+		t.Errorf("got %v, want untagged fields keyed by field name", got)
+	}
+}
+
+func TestStructToMapNotStruct(t *testing.T) {
+	t.Parallel()
+
+	_, err := arrays.StructToMap(42)
+	if !errors.Is(err, arrays.ErrNotStruct) {
+		t.Errorf("got error %v, want %v", err, arrays.ErrNotStruct)
+	}
+}
+
+func TestMapToStruct(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	email := "bob@example.com"
+
+	tests := []struct {
+		name      string
+		arr       map[string]any
+		opts      []arrays.StructOption
+		want      testUser
+		wantError error
+	}{
+		{
+			name: "decoded json document",
+			arr: map[string]any{
+				"source":     "api",
+				"id":         float64(3),
+				"name":       "Bob",
+				"email":      "bob@example.com",
+				"address":    map[string]any{"city": "Paris", "zip": "75001"},
+				"previous":   map[string]any{"city": "Lyon"},
+				"tags":       []any{"a", "b"},
+				"labels":     map[string]any{"env": "prod"},
+				"created_at": created,
+			},
+			want: testUser{
+				testMeta:  testMeta{Source: "api"},
+				ID:        3,
+				Name:      "Bob",
+				Email:     &email,
+				Address:   testAddress{City: "Paris", Zip: "75001"},
+				Previous:  &testAddress{City: "Lyon"},
+				Tags:      []string{"a", "b"},
+				Labels:    map[string]string{"env": "prod"},
+				CreatedAt: created,
+			},
+		},
+		{
+			name: "unknown and mistyped keys are ignored",
+			arr:  map[string]any{"name": "Bob", "age": 30, "id": "seven"},
+			want: testUser{Name: "Bob"},
+		},
+		{
+			name:      "strict mode rejects unknown key",
+			arr:       map[string]any{"name": "Bob", "age": 30},
+			opts:      []arrays.StructOption{arrays.WithStructStrict()},
+			wantError: arrays.ErrUnknownField,
+		},
+		{
+			name:      "strict mode rejects mistyped key",
+			arr:       map[string]any{"id": "seven"},
+			opts:      []arrays.StructOption{arrays.WithStructStrict()},
+			wantError: arrays.ErrFieldType,
+		},
+		{
+			name:      "strict mode rejects lossy number",
+			arr:       map[string]any{"id": 1.5},
+			opts:      []arrays.StructOption{arrays.WithStructStrict()},
+			wantError: arrays.ErrFieldType,
+		},
+		{
+			name:      "strict mode rejects nested mistyped key",
+			arr:       map[string]any{"address": map[string]any{"city": 1}},
+			opts:      []arrays.StructOption{arrays.WithStructStrict()},
+			wantError: arrays.ErrFieldType,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got testUser
+			err := arrays.MapToStruct(tt.arr, &got, tt.opts...)
+
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("MapToStruct() error = %v, wantError %v", err, tt.wantError)
+			}
+
+			if tt.wantError == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapToStructNotPointer(t *testing.T) {
+	t.Parallel()
+
+	var user testUser
+
+	err := arrays.MapToStruct(map[string]any{}, user)
+	if !errors.Is(err, arrays.ErrNotStruct) {
+		t.Errorf("got error %v, want %v", err, arrays.ErrNotStruct)
+	}
+}
+
+func TestStructMapRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	user := testUser{ID: 1, Name: "Carol", Address: testAddress{City: "Rome", Zip: "00100"}, Tags: []string{"x"}}
+
+	m, err := arrays.StructToMap(user)
+	if err != nil {
+		t.Fatalf("StructToMap() error = %v", err)
+	}
+
+	patch := arrays.MapMerge(nil, m, map[string]any{"name": "Caroline"})
+
+	var got testUser
+	if err := arrays.MapToStruct(patch, &got, arrays.WithStructStrict()); err != nil {
+		t.Fatalf("MapToStruct() error = %v", err)
+	}
+
+	user.Name = "Caroline"
+	if !reflect.DeepEqual(got, user) {
+		t.Errorf("got %+v, want %+v", got, user)
+	}
+}
+
+func TestStructMapJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	type schedule struct {
+		Start   time.Time      `json:"start"`
+		Window  [2]int         `json:"window"`
+		Stops   [2]testMeta    `json:"stops"`
+		Backup  *time.Time     `json:"backup"`
+		History []time.Time    `json:"history"`
+		Extra   map[string]int `json:"extra"`
+	}
+
+	start := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	want := schedule{
+		Start:   start,
+		Window:  [2]int{9, 17},
+		Stops:   [2]testMeta{{Source: "a"}, {Source: "b"}},
+		Backup:  &start,
+		History: []time.Time{start.Add(-time.Hour)},
+		Extra:   map[string]int{"x": 1},
+	}
+
+	m, err := arrays.StructToMap(want)
+	if err != nil {
+		t.Fatalf("StructToMap() error = %v", err)
+	}
+
+	// Apply the map as is and after a JSON round trip, which turns times into strings and arrays into []any.
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	for _, src := range []map[string]any{m, decoded} {
+		var got schedule
+		if err := arrays.MapToStruct(src, &got, arrays.WithStructStrict()); err != nil {
+			t.Fatalf("MapToStruct() error = %v", err)
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	}
+}
+
+func TestMapToStructTextAndArrayErrors(t *testing.T) {
+	t.Parallel()
+
+	type target struct {
+		Start  time.Time `json:"start"`
+		Window [2]int    `json:"window"`
+	}
+
+	tests := []struct {
+		name string
+		src  map[string]any
+	}{
+		{
+			name: "invalid text",
+			src:  map[string]any{"start": "yesterday"},
+		},
+		{
+			name: "array length mismatch",
+			src:  map[string]any{"window": []any{1, 2, 3}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got target
+			if err := arrays.MapToStruct(tt.src, &got, arrays.WithStructStrict()); !errors.Is(err, arrays.ErrFieldType) {
+				t.Errorf("got error %v, want %v", err, arrays.ErrFieldType)
+			}
+
+			if err := arrays.MapToStruct(tt.src, &got); err != nil || !reflect.DeepEqual(got, target{}) {
+				t.Errorf("non-strict MapToStruct() = %+v, %v, want zero value and no error", got, err)
+			}
+		})
+	}
+}
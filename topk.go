@@ -0,0 +1,60 @@
+package arrays
+
+type indexed[I any] struct {
+	value I
+	index int
+}
+
+// selectK returns k best elements of the array in order from the best, ties are broken by original index.
+// better reports whether a is strictly better than b.
+func selectK[I any](arr []I, k int, better func(a, b I) bool) []I {
+	h := NewBoundedHeap(k, func(a, b indexed[I]) bool {
+		if better(b.value, a.value) {
+			return true
+		}
+
+		if better(a.value, b.value) {
+			return false
+		}
+
+		return a.index > b.index
+	})
+
+	for i, v := range arr {
+		h.Push(indexed[I]{value: v, index: i})
+	}
+
+	return ArrayProcess(h.Sorted(), func(v indexed[I]) I {
+		return v.value
+	})
+}
+
+// ArrayTopK returns the k greatest elements of the array according to the less function, from the greatest.
+// Equal elements keep their original order. Runs in O(n log k).
+func ArrayTopK[I any](arr []I, k int, less func(a, b I) bool) []I {
+	return selectK(arr, k, func(a, b I) bool {
+		return less(b, a)
+	})
+}
+
+// ArrayBottomK returns the k least elements of the array according to the less function, from the least.
+// Equal elements keep their original order. Runs in O(n log k).
+func ArrayBottomK[I any](arr []I, k int, less func(a, b I) bool) []I {
+	return selectK(arr, k, less)
+}
+
+// ArrayTopKBy returns the k elements of the array with the greatest keys, from the greatest.
+// Elements with equal keys keep their original order. Runs in O(n log k).
+func ArrayTopKBy[I any, K Ordered](arr []I, k int, key func(value I) K) []I {
+	return ArrayTopK(arr, k, func(a, b I) bool {
+		return key(a) < key(b)
+	})
+}
+
+// ArrayBottomKBy returns the k elements of the array with the least keys, from the least.
+// Elements with equal keys keep their original order. Runs in O(n log k).
+func ArrayBottomKBy[I any, K Ordered](arr []I, k int, key func(value I) K) []I {
+	return ArrayBottomK(arr, k, func(a, b I) bool {
+		return key(a) < key(b)
+	})
+}
@@ -0,0 +1,108 @@
+package arrays_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+type score struct {
+	player string
+	points int
+}
+
+func TestArrayTopKBy(t *testing.T) {
+	t.Parallel()
+
+	scores := []score{
+		{player: "a", points: 10},
+		{player: "b", points: 30},
+		{player: "c", points: 20},
+		{player: "d", points: 30},
+		{player: "e", points: 5},
+		{player: "f", points: 20},
+	}
+
+	tests := []struct {
+		name string
+		k    int
+		top  bool
+		want []string
+	}{
+		{
+			name: "top three with stable ties",
+			k:    3,
+			top:  true,
+			want: []string{"b", "d", "c"},
+		},
+		{
+			name: "bottom three with stable ties",
+			k:    3,
+			top:  false,
+			want: []string{"e", "a", "c"},
+		},
+		{
+			name: "k greater than length",
+			k:    10,
+			top:  true,
+			want: []string{"b", "d", "c", "f", "a", "e"},
+		},
+		{
+			name: "zero k",
+			k:    0,
+			top:  true,
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			key := func(s score) int { return s.points }
+
+			var got []score
+			if tt.top {
+				got = arrays.ArrayTopKBy(scores, tt.k, key)
+			} else {
+				got = arrays.ArrayBottomKBy(scores, tt.k, key)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+
+			for i, player := range tt.want {
+				if got[i].player != player {
+					t.Errorf("got %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestArrayTopK(t *testing.T) {
+	t.Parallel()
+
+	arr := rand.New(rand.NewSource(2)).Perm(1000)
+	less := func(a, b int) bool { return a < b }
+
+	sorted := append([]int(nil), arr...)
+	sort.Ints(sorted)
+
+	top := arrays.ArrayTopK(arr, 10, less)
+	bottom := arrays.ArrayBottomK(arr, 10, less)
+
+	for i := 0; i < 10; i++ {
+		if top[i] != sorted[len(sorted)-1-i] {
+			t.Errorf("top: got %v, want %v", top[i], sorted[len(sorted)-1-i])
+		}
+
+		if bottom[i] != sorted[i] {
+			t.Errorf("bottom: got %v, want %v", bottom[i], sorted[i])
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package arrays
+
+const (
+	vectorBits  = 5
+	vectorWidth = 1 << vectorBits
+	vectorMask  = vectorWidth - 1
+)
+
+// Vector is a persistent immutable array, a 32-ary trie with a tail buffer.
+// Get, Set and Append take O(log32 n) and return new versions sharing most of their
+// structure with the original, which is never modified. It's safe for concurrent use.
+// The zero value is an empty vector ready to use.
+type Vector[T any] struct {
+	count int
+	shift uint
+	root  *vectorNode[T]
+	tail  []T
+}
+
+// vectorNode is a branch with children or a leaf with exactly vectorWidth values.
+type vectorNode[T any] struct {
+	children []*vectorNode[T]
+	values   []T
+}
+
+// NewVector creates a new vector holding a copy of the provided array.
+func NewVector[T any](arr []T) *Vector[T] {
+	return (&Vector[T]{}).Append(arr...)
+}
+
+// Len returns the number of elements.
+func (v *Vector[T]) Len() int {
+	return v.count
+}
+
+// tailOffset returns the number of elements stored in the trie.
+func (v *Vector[T]) tailOffset() int {
+	return v.count - len(v.tail)
+}
+
+// leaf returns the values of the leaf holding the index, which must be below the tail offset.
+func (v *Vector[T]) leaf(i int) []T {
+	node := v.root
+	for level := v.shift; level > 0; level -= vectorBits {
+		node = node.children[(i>>level)&vectorMask]
+	}
+
+	return node.values
+}
+
+// Get returns the element at the index, false if the index is out of range.
+func (v *Vector[T]) Get(i int) (T, bool) {
+	if i < 0 || i >= v.count {
+		return *new(T), false
+	}
+
+	if off := v.tailOffset(); i >= off {
+		return v.tail[i-off], true
+	}
+
+	return v.leaf(i)[i&vectorMask], true
+}
+
+// Set returns a new vector with the element at the index replaced,
+// the original vector and false if the index is out of range.
+func (v *Vector[T]) Set(i int, value T) (*Vector[T], bool) {
+	if i < 0 || i >= v.count {
+		return v, false
+	}
+
+	res := *v
+
+	if off := v.tailOffset(); i >= off {
+		res.tail = cowCopy(v.tail)
+		res.tail[i-off] = value
+
+		return &res, true
+	}
+
+	res.root = setPath(v.root, v.shift, i, value)
+
+	return &res, true
+}
+
+// setPath copies the nodes on the path to the index and replaces the element in the copied leaf.
+func setPath[T any](node *vectorNode[T], level uint, i int, value T) *vectorNode[T] {
+	if level == 0 {
+		leaf := &vectorNode[T]{values: cowCopy(node.values)}
+		leaf.values[i&vectorMask] = value
+
+		return leaf
+	}
+
+	branch := &vectorNode[T]{children: cowCopy(node.children)}
+	idx := (i >> level) & vectorMask
+	branch.children[idx] = setPath(node.children[idx], level-vectorBits, i, value)
+
+	return branch
+}
+
+// Append returns a new vector with the values added to the end.
+func (v *Vector[T]) Append(values ...T) *Vector[T] {
+	if len(values) == 0 {
+		return v
+	}
+
+	res := *v
+
+	for len(values) > 0 {
+		if len(res.tail) == vectorWidth {
+			res.pushTail()
+		}
+
+		// The tail is copied, so appending never writes to memory shared with other versions.
+		n := vectorWidth - len(res.tail)
+		if n > len(values) {
+			n = len(values)
+		}
+
+		tail := make([]T, len(res.tail), len(res.tail)+n)
+		copy(tail, res.tail)
+
+		res.tail = append(tail, values[:n]...)
+		res.count += n
+		values = values[n:]
+	}
+
+	return &res
+}
+
+// pushTail moves the full tail into the trie as a new leaf, adding a level when the trie is full.
+func (v *Vector[T]) pushTail() {
+	leaf := &vectorNode[T]{values: v.tail}
+	v.tail = nil
+
+	switch {
+	case v.root == nil:
+		v.root = &vectorNode[T]{children: []*vectorNode[T]{leaf}}
+		v.shift = vectorBits
+	case v.count>>vectorBits > 1<<v.shift:
+		v.root = &vectorNode[T]{children: []*vectorNode[T]{v.root, newPath(v.shift, leaf)}}
+		v.shift += vectorBits
+	default:
+		v.root = pushLeaf(v.root, v.shift, v.count-1, leaf)
+	}
+}
+
+// pushLeaf copies the nodes on the path to the last index and adds the leaf there.
+func pushLeaf[T any](node *vectorNode[T], level uint, last int, leaf *vectorNode[T]) *vectorNode[T] {
+	idx := (last >> level) & vectorMask
+
+	var child *vectorNode[T]
+
+	switch {
+	case level == vectorBits:
+		child = leaf
+	case idx < len(node.children):
+		child = pushLeaf(node.children[idx], level-vectorBits, last, leaf)
+	default:
+		child = newPath(level-vectorBits, leaf)
+	}
+
+	if idx < len(node.children) {
+		branch := &vectorNode[T]{children: cowCopy(node.children)}
+		branch.children[idx] = child
+
+		return branch
+	}
+
+	branch := &vectorNode[T]{children: make([]*vectorNode[T], len(node.children), len(node.children)+1)}
+	copy(branch.children, node.children)
+	branch.children = append(branch.children, child)
+
+	return branch
+}
+
+// newPath wraps the leaf into branches down from the level.
+func newPath[T any](level uint, leaf *vectorNode[T]) *vectorNode[T] {
+	if level == 0 {
+		return leaf
+	}
+
+	return &vectorNode[T]{children: []*vectorNode[T]{newPath(level-vectorBits, leaf)}}
+}
+
+// ForEach calls the callback for each element in order.
+func (v *Vector[T]) ForEach(callback func(key int, value T)) {
+	off := v.tailOffset()
+
+	for i := 0; i < off; i += vectorWidth {
+		for j, value := range v.leaf(i) {
+			callback(i+j, value)
+		}
+	}
+
+	for j, value := range v.tail {
+		callback(off+j, value)
+	}
+}
+
+// ToSlice returns the elements as a new array.
+func (v *Vector[T]) ToSlice() []T {
+	res := make([]T, 0, v.count)
+
+	v.ForEach(func(_ int, value T) {
+		res = append(res, value)
+	})
+
+	return res
+}
+
+// Filter returns a new vector with the elements that pass the test implemented by the provided function.
+func (v *Vector[T]) Filter(callback func(key int, value T) bool) *Vector[T] {
+	var res []T
+
+	v.ForEach(func(i int, value T) {
+		if callback(i, value) {
+			res = append(res, value)
+		}
+	})
+
+	return NewVector(res)
+}
+
+// Map returns a new vector populated with the results of calling the provided function on every element.
+func (v *Vector[T]) Map(callback func(key int, value T) T) *Vector[T] {
+	return VectorMap(v, callback)
+}
+
+// VectorMap returns a new vector populated with the results of calling the provided function
+// on every element of the vector. Unlike Vector.Map the callback may change the element type.
+func VectorMap[T, R any](v *Vector[T], callback func(key int, value T) R) *Vector[R] {
+	res := make([]R, 0, v.count)
+
+	v.ForEach(func(i int, value T) {
+		res = append(res, callback(i, value))
+	})
+
+	return NewVector(res)
+}
@@ -0,0 +1,200 @@
+package arrays_test
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/sergeyslonimsky/arrays"
+)
+
+func sequence(n int) []int {
+	r := make([]int, n)
+	for i := range r {
+		r[i] = i
+	}
+
+	return r
+}
+
+func TestVector(t *testing.T) {
+	t.Parallel()
+
+	var empty arrays.Vector[int]
+
+	if _, ok := empty.Get(0); ok || empty.Len() != 0 {
+		t.Error("zero vector is not empty")
+	}
+
+	v := empty.Append(1, 2, 3)
+
+	if got := v.ToSlice(); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Fatalf("got %v, want [1 2 3]", got)
+	}
+
+	if empty.Len() != 0 {
+		t.Error("Append() modified the original vector")
+	}
+
+	set, ok := v.Set(1, 20)
+	if !ok {
+		t.Fatal("Set(1) = false")
+	}
+
+	if got := set.ToSlice(); !reflect.DeepEqual(got, []int{1, 20, 3}) {
+		t.Errorf("got %v, want [1 20 3]", got)
+	}
+
+	if got := v.ToSlice(); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("Set() modified the original vector: %v", got)
+	}
+
+	if same, ok := v.Set(3, 0); ok || same != v {
+		t.Error("Set(3) out of range returned a new vector")
+	}
+
+	if _, ok := v.Get(-1); ok {
+		t.Error("Get(-1) returned a value")
+	}
+
+	odd := v.Filter(func(_ int, value int) bool { return value%2 == 1 })
+	if got := odd.ToSlice(); !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("Filter() = %v, want [1 3]", got)
+	}
+
+	doubled := v.Map(func(_ int, value int) int { return value * 2 })
+	if got := doubled.ToSlice(); !reflect.DeepEqual(got, []int{2, 4, 6}) {
+		t.Errorf("Map() = %v, want [2 4 6]", got)
+	}
+
+	strs := arrays.VectorMap(v, func(i int, value int) string { return strconv.Itoa(i) + ":" + strconv.Itoa(value) })
+	if got := strs.ToSlice(); !reflect.DeepEqual(got, []string{"0:1", "1:2", "2:3"}) {
+		t.Errorf("VectorMap() = %v, want [0:1 1:2 2:3]", got)
+	}
+}
+
+func TestVectorSizes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		n    int
+	}{
+		{name: "tail only", n: 31},
+		{name: "one leaf", n: 32},
+		{name: "leaf and tail", n: 33},
+		{name: "full root", n: 32*32 + 32},
+		{name: "two levels", n: 32*32 + 33},
+		{name: "three levels", n: 32*32*32 + 100},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			want := sequence(tt.n)
+
+			// Append one by one and in bulk, both must build the same vector.
+			var single arrays.Vector[int]
+
+			one := &single
+			for _, value := range want {
+				one = one.Append(value)
+			}
+
+			bulk := arrays.NewVector(want)
+
+			for _, v := range []*arrays.Vector[int]{one, bulk} {
+				if v.Len() != tt.n {
+					t.Fatalf("Len() = %d, want %d", v.Len(), tt.n)
+				}
+
+				for i := range want {
+					if got, ok := v.Get(i); !ok || got != i {
+						t.Fatalf("Get(%d) = %v, %v, want %d, true", i, got, ok, i)
+					}
+				}
+
+				if got := v.ToSlice(); !reflect.DeepEqual(got, want) {
+					t.Fatal("ToSlice() differs from the source")
+				}
+			}
+		})
+	}
+}
+
+func TestVectorStructuralSharing(t *testing.T) {
+	t.Parallel()
+
+	src := sequence(2000)
+	base := arrays.NewVector(src)
+	src[0] = -1
+
+	// Two versions grown from the same base must not see each other's elements.
+	left := base.Append(-1)
+	right := base.Append(-2)
+
+	if v, _ := left.Get(2000); v != -1 {
+		t.Errorf("left Get(2000) = %d, want -1", v)
+	}
+
+	if v, _ := right.Get(2000); v != -2 {
+		t.Errorf("right Get(2000) = %d, want -2", v)
+	}
+
+	versions := make([]*arrays.Vector[int], 0, 10)
+	for i := 0; i < 10; i++ {
+		v, _ := base.Set(i*197, -i)
+		versions = append(versions, v)
+	}
+
+	for i, v := range versions {
+		for j := 0; j < 10; j++ {
+			want := j * 197
+			if j == i {
+				want = -i
+			}
+
+			if got, _ := v.Get(j * 197); got != want {
+				t.Errorf("version %d Get(%d) = %d, want %d", i, j*197, got, want)
+			}
+		}
+	}
+
+	if got := base.ToSlice(); !reflect.DeepEqual(got, sequence(2000)) {
+		t.Error("base vector was modified")
+	}
+}
+
+func TestVectorConcurrentReads(t *testing.T) {
+	t.Parallel()
+
+	base := arrays.NewVector(sequence(5000))
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+
+		go func(w int) {
+			defer wg.Done()
+
+			v := base
+			for i := w; i < 5000; i += 8 {
+				v, _ = v.Set(i, -i)
+				v = v.Append(i)
+			}
+
+			if got, _ := v.Get(w); got != -w {
+				t.Errorf("worker %d Get(%d) = %d, want %d", w, w, got, -w)
+			}
+		}(w)
+	}
+
+	wg.Wait()
+
+	if got := base.ToSlice(); !reflect.DeepEqual(got, sequence(5000)) {
+		t.Error("base vector was modified")
+	}
+}